@@ -3,11 +3,10 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 )
 
@@ -26,34 +25,31 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
-func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
+// The lines of the input, read as they're needed.
+func Lines(input *scanner) Stream[string] {
+	return funcStream[string](input.NextLine)
+}
 
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+func main() {
+	if err := RunSolver[Readings](sonarSweep{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var (
@@ -74,63 +70,69 @@ func extractRegexp(pattern *regexp.Regexp, str string) (map[string]string, error
 	return params, nil
 }
 
-func part1(input *scanner) int {
-	// Setup
-	count := 0
-	prev := 0
-	lineNo := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
+// The depth on each line of input.
+func depths(input *scanner) *ErrStream[int] {
+	return Map(Lines(input), func(line string) (int, error) {
 		parsedLine, err := extractRegexp(inputFormat, line)
 		if err != nil {
-			log.Fatalf("input line %d: %v", count, err)
+			return 0, err
 		}
+		return strconv.Atoi(parsedLine[""])
+	})
+}
 
-		// Process line
-		val, err := strconv.Atoi(parsedLine[""])
-		if err != nil {
-			log.Fatal(err)
-		}
-		if lineNo > 0 && val > prev {
-			count++
-		}
-		lineNo++
-		prev = val
+// The sonar readings, read afresh from the input for each part rather than
+// held in memory.
+type Readings struct {
+	input io.ReadSeeker
+}
+
+// Streams the depths from the start of the input through op.
+func (r Readings) scan(op func(Stream[int]) (int, error)) (int, error) {
+	if _, err := r.input.Seek(0, io.SeekStart); err != nil {
+		return 0, err
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	scanner := newScanner(r.input)
+	vals := depths(scanner)
+	result, err := op(vals)
+	if err != nil {
+		return 0, err
 	}
-	return count
+	if err := vals.Err(); err != nil {
+		return 0, err
+	}
+	return result, scanner.Finish()
 }
 
-func part2(input *scanner) int {
-	// Setup
-	lineNo := 0
-	count := 0
-	last3 := make([]int, 3)
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		parsedLine, err := extractRegexp(inputFormat, line)
-		if err != nil {
-			log.Fatalf("input line %d: %v", count, err)
+type sonarSweep struct{}
+
+// Reads through every depth once, to be sure they all parse.
+func (sonarSweep) Parse(input io.Reader) (Readings, error) {
+	seeker, ok := input.(io.ReadSeeker)
+	if !ok {
+		return Readings{}, fmt.Errorf("the input has to be seekable, to read it once per part")
+	}
+	readings := Readings{input: seeker}
+	_, err := readings.scan(func(vals Stream[int]) (int, error) {
+		for _, ok := vals.Next(); ok; _, ok = vals.Next() {
 		}
+		return 0, nil
+	})
+	return readings, err
+}
 
-		// Process line
-		val, err := strconv.Atoi(parsedLine[""])
+func (sonarSweep) Part1(readings Readings) (Answer, error) {
+	return readings.scan(func(vals Stream[int]) (int, error) {
+		return CountIncreases(vals), nil
+	})
+}
+
+func (sonarSweep) Part2(readings Readings) (Answer, error) {
+	return readings.scan(func(vals Stream[int]) (int, error) {
+		sums, err := WindowSums(vals, 3)
 		if err != nil {
-			log.Fatal(err)
+			return 0, err
 		}
-		if lineNo >= 3 && val > last3[0] {
-			count++
-		}
-		last3[0] = last3[1]
-		last3[1] = last3[2]
-		last3[2] = val
-
-		lineNo++
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
-	}
-	return count
+		return CountIncreases(sums), nil
+	})
 }
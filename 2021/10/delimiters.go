@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Checks that delimiters in a line are balanced. Any character that doesn't
+// open a pair has to close the innermost open one, so a character that isn't
+// part of any pair corrupts the line.
+type Checker struct {
+	closerFor map[rune]rune
+	isCloser  map[rune]bool
+}
+
+// Each pair is a two-character string: the opener, then its closer.
+func NewChecker(pairs ...string) (*Checker, error) {
+	c := &Checker{
+		closerFor: make(map[rune]rune),
+		isCloser:  make(map[rune]bool),
+	}
+	for _, pair := range pairs {
+		if utf8.RuneCountInString(pair) != 2 {
+			return nil, fmt.Errorf("delimiter pair %q isn't two characters", pair)
+		}
+		opener, size := utf8.DecodeRuneInString(pair)
+		closer, _ := utf8.DecodeRuneInString(pair[size:])
+		if opener == closer {
+			return nil, fmt.Errorf("delimiter pair %q opens and closes with the same character", pair)
+		}
+		if _, seen := c.closerFor[opener]; seen || c.isCloser[opener] {
+			return nil, fmt.Errorf("delimiter %q appears in more than one pair", opener)
+		}
+		if _, seen := c.closerFor[closer]; seen || c.isCloser[closer] {
+			return nil, fmt.Errorf("delimiter %q appears in more than one pair", closer)
+		}
+		c.closerFor[opener] = closer
+		c.isCloser[closer] = true
+	}
+	return c, nil
+}
+
+// Like NewChecker, but panics if the pairs are invalid; for package-level
+// checkers, in the style of regexp.MustCompile.
+func MustNewChecker(pairs ...string) *Checker {
+	c, err := NewChecker(pairs...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+type LineStatus int
+
+const (
+	Valid LineStatus = iota
+	Corrupted
+	Incomplete
+)
+
+func (s LineStatus) String() string {
+	switch s {
+	case Valid:
+		return "valid"
+	case Corrupted:
+		return "corrupted"
+	case Incomplete:
+		return "incomplete"
+	default:
+		return "err"
+	}
+}
+
+type LineResult struct {
+	Status LineStatus
+
+	// For corrupted lines: the (rune) index of the first bad character, the
+	// character that was found there, and the closer that would have been
+	// valid (0 if nothing was open).
+	Pos      int
+	Found    rune
+	Expected rune
+
+	// For incomplete lines: the closers needed to finish the line, innermost
+	// first.
+	Completion string
+}
+
+func (c *Checker) Check(line string) LineResult {
+	stack := []rune{}
+	pos := 0
+	for _, char := range line {
+		if closer, isOpener := c.closerFor[char]; isOpener {
+			stack = append(stack, closer)
+		} else if len(stack) == 0 {
+			return LineResult{Status: Corrupted, Pos: pos, Found: char}
+		} else if expected := stack[len(stack)-1]; char != expected {
+			return LineResult{Status: Corrupted, Pos: pos, Found: char, Expected: expected}
+		} else {
+			stack = stack[:len(stack)-1]
+		}
+		pos++
+	}
+
+	if len(stack) == 0 {
+		return LineResult{Status: Valid}
+	}
+	var completion strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		completion.WriteRune(stack[i])
+	}
+	return LineResult{Status: Incomplete, Completion: completion.String()}
+}
+
+// Scores a checked line, or reports false if the line doesn't get a score.
+type ScoreFunc func(LineResult) (int, bool)
+
+// Scores corrupted lines by the closer that broke them.
+func CorruptedScore(points map[rune]int) ScoreFunc {
+	return func(r LineResult) (int, bool) {
+		if r.Status != Corrupted {
+			return 0, false
+		}
+		return points[r.Found], true
+	}
+}
+
+// Scores incomplete lines by treating the completion as digits in the given
+// base, with each closer's value taken from points.
+func CompletionScore(points map[rune]int, base int) ScoreFunc {
+	return func(r LineResult) (int, bool) {
+		if r.Status != Incomplete {
+			return 0, false
+		}
+		score := 0
+		for _, char := range r.Completion {
+			score = score*base + points[char]
+		}
+		return score, true
+	}
+}
@@ -3,10 +3,9 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
-	"runtime"
 	"sort"
 )
 
@@ -25,42 +24,29 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
-
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+	if err := RunSolver[[]LineResult](syntaxScoring{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
-var matches = map[rune]rune{
-	'{': '}',
-	'[': ']',
-	'<': '>',
-	'(': ')',
-}
+var checker = MustNewChecker("()", "[]", "{}", "<>")
 
 var scores = map[rune]int{
 	')': 3,
@@ -69,43 +55,6 @@ var scores = map[rune]int{
 	'>': 25137,
 }
 
-func part1(input *scanner) int {
-	// Setup
-	lineNo := 0
-
-	totalScore := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		stack := []rune{}
-
-		thisLineScore := 0
-		for i, char := range line {
-			if _, isOpener := matches[char]; isOpener {
-				//				log.Printf("adding %s to stack", string(char))
-				stack = append(stack, char)
-			} else if char == matches[stack[len(stack)-1]] {
-				//				log.Printf("popping %s from stack", string(stack[len(stack)-1]))
-				stack = stack[:len(stack)-1]
-			} else {
-				// this one is invalid
-				thisLineScore = scores[char]
-				log.Printf("Invalid char %s at pos %d of line %d; scoring %d", string(char), i, lineNo, thisLineScore)
-				break
-			}
-		}
-
-		totalScore += thisLineScore
-		if thisLineScore == 0 {
-			log.Printf("found no invalid in %s", line)
-		}
-		lineNo++
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
-	}
-
-	return totalScore
-}
-
 var completionScores = map[rune]int{
 	')': 1,
 	']': 2,
@@ -113,55 +62,60 @@ var completionScores = map[rune]int{
 	'>': 4,
 }
 
-func part2(input *scanner) int {
-	// Setup
-	lineNo := 0
-
-	lineScores := []int{}
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		stack := []rune{}
-
-		isInvalid := false
-		for i, char := range line {
-			if _, isOpener := matches[char]; isOpener {
-				//				log.Printf("adding %s to stack", string(char))
-				stack = append(stack, char)
-			} else if char == matches[stack[len(stack)-1]] {
-				//				log.Printf("popping %s from stack", string(stack[len(stack)-1]))
-				stack = stack[:len(stack)-1]
-			} else {
-				// this one is invalid
-				isInvalid = true
-				log.Printf("Invalid char %s at pos %d of line %d", string(char), i, lineNo)
-				break
-			}
-		}
-
-		if isInvalid {
-			continue
+type syntaxScoring struct{}
+
+// Checks each line of input; both parts score the same results.
+func (syntaxScoring) Parse(input io.Reader) ([]LineResult, error) {
+	scanner := newScanner(input)
+	lineNo := 1
+	results := []LineResult{}
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
+		result := checker.Check(line)
+		switch result.Status {
+		case Corrupted:
+			log.Printf("Invalid char %s at pos %d of line %d (expected %q)", string(result.Found), result.Pos, lineNo, result.Expected)
+		case Incomplete:
+			log.Printf("line %d needs %s", lineNo, result.Completion)
 		}
+		results = append(results, result)
+		lineNo++
+	}
+	if err := scanner.Finish(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
 
-		log.Printf("remaining: %s", string(stack))
-		thisLineScore := 0
-		for len(stack) > 0 {
-			thisLineScore *= 5
-			thisLineScore += completionScores[matches[stack[len(stack)-1]]]
-			stack = stack[:len(stack)-1]
-			log.Printf("score: %d", thisLineScore)
+// The scores of those lines that get one.
+func scoreLines(results []LineResult, score ScoreFunc) []int {
+	lineScores := []int{}
+	for _, result := range results {
+		if s, ok := score(result); ok {
+			lineScores = append(lineScores, s)
 		}
-		log.Printf("completion score: %d", thisLineScore)
-		lineScores = append(lineScores, thisLineScore)
-		lineNo++
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	return lineScores
+}
+
+func (syntaxScoring) Part1(results []LineResult) (Answer, error) {
+	totalScore := 0
+	for _, s := range scoreLines(results, CorruptedScore(scores)) {
+		totalScore += s
 	}
+	return totalScore, nil
+}
+
+func (syntaxScoring) Part2(results []LineResult) (Answer, error) {
+	lineScores := scoreLines(results, CompletionScore(completionScores, 5))
 
 	sort.Sort(sort.IntSlice(lineScores))
 	log.Printf("got %d incomplete lines", len(lineScores))
+	if len(lineScores) == 0 {
+		return nil, fmt.Errorf("no incomplete lines")
+	}
 
 	middle := len(lineScores) / 2
 	log.Printf("middle index is %d", middle)
 
-	return lineScores[middle]
+	return lineScores[middle], nil
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// Writes a random grid of octopuses that all flash together within maxSteps
+// steps. Most grids of random digits never do, so grids are drawn until one
+// does, up to tries of them.
+func generateSyncingGrid(w io.Writer, rng *rand.Rand, width, height, maxSteps, tries int) error {
+	for try := 0; try < tries; try++ {
+		var sb strings.Builder
+		if err := generateDigitGrid(&sb, rng, width, height, 0, 9); err != nil {
+			return err
+		}
+		octoState, err := readDigitGrid(strings.NewReader(sb.String()))
+		if err != nil {
+			return err
+		}
+		for i := 1; i <= maxSteps; i++ {
+			if step(octoState) == width*height {
+				_, err := io.WriteString(w, sb.String())
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("none of %d random %dx%d grids all flashed within %d steps", tries, width, height, maxSteps)
+}
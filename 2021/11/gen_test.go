@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedGridsSync(t *testing.T) {
+	// Part2 logs every step
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for seed := int64(1); seed <= 3; seed++ {
+		var sb strings.Builder
+		if err := generateSyncingGrid(&sb, rand.New(rand.NewSource(seed)), 10, 10, *maxSteps, 100); err != nil {
+			t.Fatal(err)
+		}
+		octoState, err := dumboOctopus{}.Parse(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := (dumboOctopus{}).Part2(octoState); err != nil {
+			t.Errorf("seed %d: %v\n%s", seed, err, sb.String())
+		}
+	}
+}
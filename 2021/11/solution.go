@@ -1,12 +1,75 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"image"
+	"image/color"
+	"io"
 	"log"
+	"math/rand"
+	"os"
+)
+
+var (
+	animate    = flag.Bool("animate", false, "show every step in the terminal as it happens")
+	fps        = flag.Float64("fps", 10, "steps per second to show with -animate")
+	framesPath = flag.String("frames", "", "write every step as text to this file")
+	generate   = flag.Bool("generate", false, "instead of solving, print a random grid of octopuses, one that all flash together within -maxsteps, to use as input")
+	seed       = flag.Int64("seed", 1, "random seed for -generate")
+	width      = flag.Int("width", 10, "how wide a grid -generate makes")
+	height     = flag.Int("height", 10, "how tall a grid -generate makes")
+	maxSteps   = flag.Int("maxsteps", 100000, "give up on part 2 after this many steps, since a random grid might never all flash at once")
 )
 
 func main() {
-	doBoth()
+	flag.Parse()
+	if *generate {
+		if err := generateSyncingGrid(os.Stdout, rand.New(rand.NewSource(*seed)), *width, *height, *maxSteps, 100); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := RunSolver[[][]int](dumboOctopus{}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// With -animate or -frames, shows the steps before the answers are printed.
+func (dumboOctopus) BeforeSolving(octoState [][]int) (bool, error) {
+	if !*animate && *framesPath == "" {
+		return true, nil
+	}
+	return true, watch(cloneGrid(octoState))
+}
+
+// Where to show each step, if anywhere.
+func openScreen() (*Screen, error) {
+	if *animate {
+		screen, err := NewLiveScreen(*fps)
+		if err != nil {
+			return nil, err
+		}
+		// logging would scribble over the animation
+		log.SetOutput(io.Discard)
+		return screen, nil
+	}
+	if *framesPath != "" {
+		return CreateHeadlessScreen(*framesPath)
+	}
+	return nil, nil
+}
+
+// Octopuses that just flashed are bright; the rest glow brighter the closer
+// they are to flashing.
+var octoPalette = append(
+	color.Palette{color.RGBA{R: 255, G: 250, B: 200, A: 255}},
+	Ramp(color.RGBA{R: 10, G: 15, B: 40, A: 255}, color.RGBA{R: 60, G: 110, B: 200, A: 255}, 9)...)
+
+func renderOctopuses(octoState [][]int) *image.Paletted {
+	return RenderGrid(len(octoState[0]), len(octoState), 20, octoPalette, func(x, y int) int {
+		return octoState[y][x]
+	})
 }
 
 type Point struct {
@@ -26,7 +89,7 @@ func blinkFrom(blinker Point, octoState [][]int) {
 		{row: blinker.row + 1, col: blinker.col + 1},
 	}
 	for _, n := range neighbors {
-		if n.row >= 0 && n.row < 10 && n.col >= 0 && n.col < 10 {
+		if n.row >= 0 && n.row < len(octoState) && n.col >= 0 && n.col < len(octoState[n.row]) {
 			// real point
 			octoState[n.row][n.col]++
 			if octoState[n.row][n.col] == 10 {
@@ -37,56 +100,131 @@ func blinkFrom(blinker Point, octoState [][]int) {
 	}
 }
 
-func doBoth() {
-	octoState := [][]int{
-		{7, 7, 7, 7, 8, 3, 8, 3, 5, 3},
-		{2, 2, 1, 7, 2, 7, 2, 4, 7, 8},
-		{3, 3, 5, 5, 3, 1, 8, 6, 4, 5},
-		{2, 2, 4, 2, 6, 1, 8, 1, 1, 3},
-		{7, 1, 8, 2, 4, 6, 8, 6, 6, 6},
-		{5, 4, 4, 1, 6, 4, 1, 1, 1, 1},
-		{4, 7, 7, 3, 8, 6, 2, 3, 6, 4},
-		{5, 7, 1, 7, 1, 2, 5, 5, 2, 1},
-		{7, 5, 4, 2, 1, 2, 7, 7, 2, 1},
-		{4, 5, 7, 6, 6, 7, 8, 3, 4, 1},
-	}
+// Advances every octopus by a step, and returns how many flashed.
+func step(octoState [][]int) int {
+	blinksThisStep := 0
 
-	blinks := 0
-	foundSynchronizedBlink := false
-	for step := 1; step <= 100 || !foundSynchronizedBlink; step++ {
-		blinksThisStep := 0
-
-		for r := 0; r < 10; r++ {
-			for c := 0; c < 10; c++ {
-				octoState[r][c]++
-				if octoState[r][c] == 10 {
-					// if >10, it's already been triggered
-					blinkFrom(Point{row: r, col: c}, octoState)
-				}
+	for r := range octoState {
+		for c := range octoState[r] {
+			octoState[r][c]++
+			if octoState[r][c] == 10 {
+				// if >10, it's already been triggered
+				blinkFrom(Point{row: r, col: c}, octoState)
 			}
 		}
+	}
 
-		for r := 0; r < 10; r++ {
-			for c := 0; c < 10; c++ {
-				if octoState[r][c] > 9 {
-					octoState[r][c] = 0
-					blinksThisStep++
-				}
+	for r := range octoState {
+		for c := range octoState[r] {
+			if octoState[r][c] > 9 {
+				octoState[r][c] = 0
+				blinksThisStep++
 			}
 		}
+	}
+	return blinksThisStep
+}
 
-		blinks += blinksThisStep
+func cloneGrid(octoState [][]int) [][]int {
+	clone := make([][]int, len(octoState))
+	for r, row := range octoState {
+		clone[r] = append([]int{}, row...)
+	}
+	return clone
+}
+
+type dumboOctopus struct{}
+
+func (dumboOctopus) Parse(input io.Reader) ([][]int, error) {
+	return readDigitGrid(input)
+}
+
+func (dumboOctopus) Part1(octoState [][]int) (Answer, error) {
+	octoState = cloneGrid(octoState)
+	blinks := 0
+	for i := 1; i <= 100; i++ {
+		blinks += step(octoState)
+	}
+	return blinks, nil
+}
+
+func (dumboOctopus) Part2(octoState [][]int) (Answer, error) {
+	octoState = cloneGrid(octoState)
+	for i := 1; i <= *maxSteps; i++ {
+		blinksThisStep := step(octoState)
 		for _, row := range octoState {
 			log.Printf("%v\n", row)
 		}
-		log.Printf("%d blinks on step %d\n", blinksThisStep, step)
+		log.Printf("%d blinks on step %d\n", blinksThisStep, i)
+		if blinksThisStep == len(octoState)*len(octoState[0]) {
+			return i, nil
+		}
+	}
+	return nil, fmt.Errorf("no step in the first %d has every octopus flash", *maxSteps)
+}
+
+// Shows the steps on the screen -animate or -frames asks for, before the
+// answers are printed.
+func watch(octoState [][]int) (err error) {
+	screen, err := openScreen()
+	if err != nil || screen == nil {
+		return err
+	}
+	defer func() {
+		if cerr := screen.Close(); err == nil {
+			err = cerr
+		}
+		log.SetOutput(os.Stderr)
+	}()
+	return showSteps(screen, octoState)
+}
 
-		if step == 100 {
-			fmt.Printf("Part 1 solution: %d\n", blinks)
+// Draws the grid on screen, then steps it, until the first step where they
+// all flash (or the hundredth, if that's later), or until the viewer quits.
+func showSteps(screen *Screen, octoState [][]int) error {
+	blinks := 0
+	caption := "start"
+	foundSynchronizedBlink := false
+	for i := 1; ; i++ {
+		err := screen.Draw(len(octoState[0]), len(octoState), octoPalette, func(x, y int) int { return octoState[y][x] }, caption)
+		if err == ErrQuit {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if i > 100 && (foundSynchronizedBlink || i > *maxSteps) {
+			return nil
+		}
+
+		blinksThisStep := step(octoState)
+		blinks += blinksThisStep
+		caption = fmt.Sprintf("step %d, %d flashes (%d in all)", i, blinksThisStep, blinks)
+		if blinksThisStep == len(octoState)*len(octoState[0]) {
+			foundSynchronizedBlink = true
+		}
+	}
+}
+
+// A GIF holds every frame in memory until it's written, and a random grid can
+// take all of -maxsteps to synchronize, so recordings stop after this many.
+const maxGIFFrames = 1000
+
+// Records every step up to the first where they all flash (or the hundredth,
+// if that's later) as an animated GIF.
+func (dumboOctopus) Draw(octoState [][]int, path string) error {
+	octoState = cloneGrid(octoState)
+	recorder := &Recorder{Delay: 10, MaxFrames: maxGIFFrames}
+	recorder.AddFrame(renderOctopuses(octoState))
+	foundSynchronizedBlink := false
+	for i := 1; i <= 100 || (!foundSynchronizedBlink && i <= *maxSteps); i++ {
+		blinksThisStep := step(octoState)
+		if !recorder.AddFrame(renderOctopuses(octoState)) {
+			log.Printf("stopped recording at step %d, after %d frames", i-1, recorder.Frames())
+			break
 		}
-		if blinksThisStep == 100 {
-			fmt.Printf("Part 2 solution: %d\n", step)
+		if blinksThisStep == len(octoState)*len(octoState[0]) {
 			foundSynchronizedBlink = true
 		}
 	}
+	return recorder.WriteGIF(path)
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// The larger example from the puzzle, which first flashes all at once on
+// step 195.
+const exampleGrid = `5483143223
+2745854711
+5264556173
+6141336146
+6357385478
+4167524645
+2176841721
+6882881134
+4846848554
+5283751526
+`
+
+func TestShowSteps(t *testing.T) {
+	octoState, err := dumboOctopus{}.Parse(strings.NewReader(exampleGrid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	screen := NewHeadlessScreen(&out)
+	if err := showSteps(screen, octoState); err != nil {
+		t.Fatal(err)
+	}
+	if err := screen.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	frames := strings.Split(strings.TrimSuffix(out.String(), "\n\n"), "\n\n")
+	want := []string{
+		"frame 1: start\n" + strings.TrimSuffix(exampleGrid, "\n"),
+		`frame 2: step 1, 0 flashes (0 in all)
+6594254334
+3856965822
+6375667284
+7252447257
+7468496589
+5278635756
+3287952832
+7993992245
+5957959665
+6394862637`,
+		`frame 3: step 2, 35 flashes (35 in all)
+8807476555
+5089087054
+8597889608
+8485769600
+8700908800
+6600088989
+6800005943
+0000007456
+9000000876
+8700006848`,
+	}
+	for i, w := range want {
+		if frames[i] != w {
+			t.Errorf("frame %d:\n%s\nwant:\n%s", i+1, frames[i], w)
+		}
+	}
+	// every step up to and including the first where they all flash
+	last := frames[len(frames)-1]
+	if wantLast := "frame 196: step 195, 100 flashes ("; !strings.HasPrefix(last, wantLast) {
+		t.Errorf("last frame:\n%s\nwant it to start %q", last, wantLast)
+	}
+	if !strings.HasSuffix(last, strings.Repeat("\n0000000000", 10)) {
+		t.Errorf("last frame:\n%s\nwant every octopus at 0", last)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// A cave name that isn't taken yet: two letters (more once those run out),
+// upper case for big caves and lower case for small ones.
+func caveName(rng *rand.Rand, big bool, taken map[string]bool) string {
+	letters := "abcdefghijklmnopqrstuvwxyz"
+	if big {
+		letters = strings.ToUpper(letters)
+	}
+	for length := 2; ; length++ {
+		for try := 0; try < 100; try++ {
+			name := make([]byte, length)
+			for i := range name {
+				name[i] = letters[rng.Intn(len(letters))]
+			}
+			if !taken[string(name)] {
+				taken[string(name)] = true
+				return string(name)
+			}
+		}
+	}
+}
+
+// Writes a random cave system in the puzzle's format: start, end, and the
+// given number of small and big caves, joined up so every cave can be reached,
+// plus extra tunnels at random. Two big caves are never joined, since then
+// there'd be no end to the paths.
+func generateCaves(w io.Writer, rng *rand.Rand, small int, big int, extra int) error {
+	if small+big == 0 {
+		return fmt.Errorf("need at least one cave between start and end")
+	}
+	taken := map[string]bool{"start": true, "end": true}
+	names := []string{"start", "end"}
+	isBig := map[string]bool{}
+	for i := 0; i < small+big; i++ {
+		name := caveName(rng, i >= small, taken)
+		isBig[name] = i >= small
+		names = append(names, name)
+	}
+	// shuffle the caves between start and end, so big ones aren't all last
+	rng.Shuffle(len(names)-2, func(i, j int) { names[i+2], names[j+2] = names[j+2], names[i+2] })
+
+	type tunnel struct{ a, b string }
+	joined := map[tunnel]bool{}
+	tunnels := []tunnel{}
+	join := func(a, b string) bool {
+		if a == b || (isBig[a] && isBig[b]) || (a == "start" && b == "end") || (a == "end" && b == "start") || joined[tunnel{a, b}] || joined[tunnel{b, a}] {
+			return false
+		}
+		joined[tunnel{a, b}] = true
+		tunnels = append(tunnels, tunnel{a, b})
+		return true
+	}
+
+	// a random tree over everything but end, so each cave can be reached
+	// from start, then end hung off one of the caves
+	for i := 2; i < len(names); i++ {
+		// anything before it in names, except end
+		to := names[0]
+		if pick := rng.Intn(i - 1); pick > 0 {
+			to = names[pick+1]
+		}
+		if !join(names[i], to) {
+			// two big caves; start always works instead
+			join(names[i], "start")
+		}
+	}
+	join("end", names[2+rng.Intn(len(names)-2)])
+
+	for added, tries := 0, 0; added < extra && tries < 100*extra; tries++ {
+		if join(names[rng.Intn(len(names))], names[rng.Intn(len(names))]) {
+			added++
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	for _, t := range tunnels {
+		if rng.Intn(2) == 0 {
+			t.a, t.b = t.b, t.a
+		}
+		fmt.Fprintf(out, "%s-%s\n", t.a, t.b)
+	}
+	return out.Flush()
+}
@@ -2,14 +2,14 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
-	"strings"
 	"unicode"
 	"unicode/utf8"
 )
@@ -29,42 +29,56 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 var (
 	inputFormat = regexp.MustCompile("(?P<sCaveA>[a-zA-Z]+)-(?P<sCaveB>[a-zA-Z]+)")
+
+	generate    = flag.Bool("generate", false, "instead of solving, print a random cave system to use as input")
+	seed        = flag.Int64("seed", 1, "random seed for -generate")
+	smallCaves  = flag.Int("small", 6, "how many small caves -generate makes, besides start and end")
+	bigCaves    = flag.Int("big", 2, "how many big caves -generate makes")
+	extraTunnel = flag.Int("extra", 4, "how many tunnels -generate adds beyond those needed to reach every cave")
 )
 
 func main() {
-	scanner := getInputScanner()
-	// Setup
-	lineNo := 0
+	flag.Parse()
+	if *generate {
+		if err := generateCaves(os.Stdout, rand.New(rand.NewSource(*seed)), *smallCaves, *bigCaves, *extraTunnel); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := RunSolver[map[string]*Cave](passagePathing{}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type passagePathing struct{}
+
+func (passagePathing) Parse(input io.Reader) (map[string]*Cave, error) {
+	scanner := newScanner(input)
+	lineNo := 1
 	caves := make(map[string]*Cave)
 	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
 		// Parse line into components
 		parsedLine, err := extractRegexp(inputFormat, line)
 		if err != nil {
-			log.Fatalf("input line %d: %v", lineNo, err)
+			return nil, fmt.Errorf("input line %d: %w", lineNo, err)
 		}
 
 		// Process line
@@ -102,29 +116,22 @@ func main() {
 		lineNo++
 	}
 	if err := scanner.Finish(); err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	fmt.Printf("Part 1 solution: %d\n",
-		getAllPaths(caves["start"], caves["end"], map[*Cave]struct{}{}, false, 0, ""))
-	fmt.Printf("Part 2 solution: %d\n",
-		getAllPaths(caves["start"], caves["end"], map[*Cave]struct{}{}, true, 0, ""))
-}
-
-func parseIntArrayOrDie(text string) []int64 {
-	res := make([]int64, 0)
-	text = strings.TrimSpace(text)
-	elements := strings.Split(text, ",")
-
-	for _, e := range elements {
-		i, err := strconv.ParseInt(e, 10, 64)
-		if err != nil {
-			panic(err)
+	for _, name := range []string{"start", "end"} {
+		if _, exists := caves[name]; !exists {
+			return nil, fmt.Errorf("no tunnel leads to %s", name)
 		}
-		res = append(res, i)
 	}
+	return caves, nil
+}
+
+func (passagePathing) Part1(caves map[string]*Cave) (Answer, error) {
+	return getAllPaths(caves["start"], caves["end"], map[*Cave]struct{}{}, false, 0, ""), nil
+}
 
-	return res
+func (passagePathing) Part2(caves map[string]*Cave) (Answer, error) {
+	return getAllPaths(caves["start"], caves["end"], map[*Cave]struct{}{}, true, 0, ""), nil
 }
 
 type parsedParams struct {
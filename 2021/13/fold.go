@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type Dir int
+
+const (
+	HORIZ Dir = iota
+	VERT
+)
+
+func (d Dir) String() string {
+	switch d {
+	case HORIZ:
+		return "y="
+	case VERT:
+		return "x="
+	default:
+		return "err"
+	}
+}
+
+type Fold struct {
+	Dir Dir
+	Val int
+}
+
+// Where a fold sends a coordinate along its axis; false if the coordinate is
+// on the fold line itself.
+func (f Fold) apply(v int) (int, bool) {
+	if v < f.Val {
+		return v, true
+	} else if v > f.Val {
+		return 2*f.Val - v, true
+	}
+	return 0, false
+}
+
+// The composition of every fold along one axis, as a table from each
+// coordinate on the unfolded sheet to where it ends up.
+type axisMap struct {
+	to   []int
+	kept []bool
+	from map[int][]int
+}
+
+func newAxisMap(size int, folds []Fold) *axisMap {
+	m := &axisMap{
+		to:   make([]int, size),
+		kept: make([]bool, size),
+		from: make(map[int][]int),
+	}
+	for v := 0; v < size; v++ {
+		m.to[v], m.kept[v] = v, true
+		for _, f := range folds {
+			if m.to[v], m.kept[v] = f.apply(m.to[v]); !m.kept[v] {
+				break
+			}
+		}
+		if m.kept[v] {
+			m.from[m.to[v]] = append(m.from[m.to[v]], v)
+		}
+	}
+	return m
+}
+
+// A sequence of folds, flattened into one lookup per axis.
+type FoldMap struct {
+	Width  int
+	Height int
+	x      *axisMap
+	y      *axisMap
+}
+
+// Works out the sheet's size (each fold should halve it exactly, so the
+// first fold on each axis says how big the sheet is, even if the outermost
+// rows or columns have no dots), checks every fold bisects what's left, and
+// composes them.
+func ComposeFolds(folds []Fold, dots map[Point]struct{}) (*FoldMap, error) {
+	m := &FoldMap{}
+	for p := range dots {
+		if p.X+1 > m.Width {
+			m.Width = p.X + 1
+		}
+		if p.Y+1 > m.Height {
+			m.Height = p.Y + 1
+		}
+	}
+	xFolds, yFolds := []Fold{}, []Fold{}
+	for _, f := range folds {
+		if f.Dir == VERT {
+			if len(xFolds) == 0 && 2*f.Val+1 > m.Width {
+				m.Width = 2*f.Val + 1
+			}
+			xFolds = append(xFolds, f)
+		} else {
+			if len(yFolds) == 0 && 2*f.Val+1 > m.Height {
+				m.Height = 2*f.Val + 1
+			}
+			yFolds = append(yFolds, f)
+		}
+	}
+
+	width, height := m.Width, m.Height
+	for i, f := range folds {
+		size := &width
+		if f.Dir == HORIZ {
+			size = &height
+		}
+		if 2*f.Val+1 != *size {
+			return nil, fmt.Errorf("fold %d (%v%d) doesn't bisect the sheet, which is %d wide along that axis by then", i+1, f.Dir, f.Val, *size)
+		}
+		*size = f.Val
+	}
+
+	m.x = newAxisMap(m.Width, xFolds)
+	m.y = newAxisMap(m.Height, yFolds)
+	for p := range dots {
+		if _, ok := m.Apply(p); !ok {
+			return nil, fmt.Errorf("dot %v lies on a fold line", p)
+		}
+	}
+	return m, nil
+}
+
+// Where the dot ends up once every fold is done; false if it's on a fold line.
+func (m *FoldMap) Apply(p Point) (Point, bool) {
+	if p.X < 0 || p.X >= m.Width || p.Y < 0 || p.Y >= m.Height {
+		return Point{}, false
+	}
+	if !m.x.kept[p.X] || !m.y.kept[p.Y] {
+		return Point{}, false
+	}
+	return Point{X: m.x.to[p.X], Y: m.y.to[p.Y]}, true
+}
+
+func (m *FoldMap) ApplyAll(dots map[Point]struct{}) map[Point]struct{} {
+	folded := make(map[Point]struct{})
+	for p := range dots {
+		if q, ok := m.Apply(p); ok {
+			folded[q] = struct{}{}
+		}
+	}
+	return folded
+}
+
+// Every position on the unfolded sheet that folds onto p, sorted by row then
+// column.
+func (m *FoldMap) PreImages(p Point) []Point {
+	preImages := []Point{}
+	for _, y := range m.y.from[p.Y] {
+		for _, x := range m.x.from[p.X] {
+			preImages = append(preImages, Point{X: x, Y: y})
+		}
+	}
+	sort.Slice(preImages, func(i, j int) bool {
+		if preImages[i].Y != preImages[j].Y {
+			return preImages[i].Y < preImages[j].Y
+		}
+		return preImages[i].X < preImages[j].X
+	})
+	return preImages
+}
+
+// The original dots that folded onto p.
+func (m *FoldMap) Sources(p Point, dots map[Point]struct{}) []Point {
+	sources := []Point{}
+	for _, q := range m.PreImages(p) {
+		if _, hit := dots[q]; hit {
+			sources = append(sources, q)
+		}
+	}
+	return sources
+}
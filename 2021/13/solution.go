@@ -2,12 +2,15 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"image"
+	"image/color"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 )
@@ -27,57 +30,74 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
-}
-
-func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
-
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: \n%s\n", part2(scanner))
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 var (
-	inputFormat = regexp.MustCompile("(?P<iX>\\d+),(?P<iY>\\d+)")
-	foldFormat  = regexp.MustCompile("fold along (?P<sDim>\\w)=(?P<iVal>\\d+)")
+	origin     = flag.String("origin", "", "instead of solving, list the original dots that fold onto this x,y")
+	animate    = flag.Bool("animate", false, "show every fold in the terminal")
+	fps        = flag.Float64("fps", 1, "folds per second to show with -animate")
+	framesPath = flag.String("frames", "", "write every fold as text to this file")
 )
 
-func parseIntArrayOrDie(text string) []int64 {
-	res := make([]int64, 0)
-	text = strings.TrimSpace(text)
-	elements := strings.Split(text, ",")
+func main() {
+	flag.Parse()
+	if err := RunSolver[Paper](transparentOrigami{}); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	for _, e := range elements {
-		i, err := strconv.ParseInt(e, 10, 64)
+// With -origin, traces the dots back instead of solving; with -animate or
+// -frames, shows the folds before the answers are printed.
+func (transparentOrigami) BeforeSolving(paper Paper) (bool, error) {
+	if *origin != "" {
+		p, err := extractRegexp(inputFormat, *origin)
 		if err != nil {
-			panic(err)
+			return false, fmt.Errorf("-origin: %w", err)
 		}
-		res = append(res, i)
+		return false, traceOrigin(paper, Point{X: p.Numbers["X"], Y: p.Numbers["Y"]})
+	}
+	if *animate || *framesPath != "" {
+		return true, watch(paper)
 	}
+	return true, nil
+}
 
-	return res
+// Where to show each fold, if anywhere.
+func openScreen() (*Screen, error) {
+	if *animate {
+		screen, err := NewLiveScreen(*fps)
+		if err != nil {
+			return nil, err
+		}
+		// logging would scribble over the animation
+		log.SetOutput(io.Discard)
+		return screen, nil
+	}
+	if *framesPath != "" {
+		return CreateHeadlessScreen(*framesPath)
+	}
+	return nil, nil
 }
 
+var (
+	inputFormat = regexp.MustCompile("(?P<iX>\\d+),(?P<iY>\\d+)")
+	foldFormat  = regexp.MustCompile("fold along (?P<sDim>\\w)=(?P<iVal>\\d+)")
+)
+
 type parsedParams struct {
 	FullMatch string
 	Strings   map[string]string
@@ -118,35 +138,20 @@ type Point struct {
 	Y int
 }
 
-type Dir int
-
-const (
-	HORIZ Dir = iota
-	VERT
-)
-
-func (d Dir) String() string {
-	switch d {
-	case HORIZ:
-		return "y="
-	case VERT:
-		return "x="
-	default:
-		return "err"
-	}
+// The dots on the unfolded sheet, and the folds to make in it.
+type Paper struct {
+	Dots  map[Point]struct{}
+	Folds []Fold
 }
 
-type Fold struct {
-	Dir Dir
-	Val int
-}
+type transparentOrigami struct{}
 
-func part1(input *scanner) int {
-	// Setup
+func (transparentOrigami) Parse(input io.Reader) (Paper, error) {
+	scanner := newScanner(input)
 	lineNo := 0
 	dots := make(map[Point]struct{})
 	folds := []Fold{}
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
 		// Parse line into components
 		if parsedLine, err := extractRegexp(inputFormat, line); err == nil {
 			dots[Point{X: parsedLine.Numbers["X"], Y: parsedLine.Numbers["Y"]}] = struct{}{}
@@ -159,100 +164,37 @@ func part1(input *scanner) int {
 		} else if len(line) == 0 {
 			//meh
 		} else {
-			log.Fatalf("couldn't parse line %d: %s", lineNo+1, line)
+			return Paper{}, fmt.Errorf("couldn't parse line %d: %s", lineNo+1, line)
 		}
 
 		lineNo++
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	if err := scanner.Finish(); err != nil {
+		return Paper{}, err
 	}
-
-	log.Printf("starting with %d dots", len(dots))
-
-	newDots := make(map[Point]struct{})
-	for p := range dots {
-		if folds[0].Dir == VERT && p.X < folds[0].Val {
-			newDots[p] = struct{}{}
-			log.Printf("keeping %v for instruction %v", p, folds[0])
-		} else if folds[0].Dir == HORIZ && p.Y < folds[0].Val {
-			newDots[p] = struct{}{}
-			log.Printf("keeping %v for instruction %v", p, folds[0])
-		} else if folds[0].Dir == VERT && p.X > folds[0].Val {
-			distance := p.X - folds[0].Val
-			newPoint := Point{X: folds[0].Val - distance, Y: p.Y}
-			newDots[newPoint] = struct{}{}
-			log.Printf("flipping %v to %v for instruction %v", p, newPoint, folds[0])
-		} else if folds[0].Dir == HORIZ && p.Y > folds[0].Val {
-			distance := p.Y - folds[0].Val
-			newPoint := Point{X: p.X, Y: folds[0].Val - distance}
-			newDots[newPoint] = struct{}{}
-			log.Printf("flipping %v to %v for instruction %v", p, newPoint, folds[0])
-		} else {
-			log.Printf("point %v is on the line for instruction %v", p, folds[0])
-		}
+	if len(folds) == 0 {
+		return Paper{}, fmt.Errorf("no folds")
 	}
 
-	return len(newDots)
+	log.Printf("starting with %d dots", len(dots))
+	return Paper{Dots: dots, Folds: folds}, nil
 }
 
-func part2(input *scanner) string {
-	// Setup
-	lineNo := 0
-	dots := make(map[Point]struct{})
-	folds := []Fold{}
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		if parsedLine, err := extractRegexp(inputFormat, line); err == nil {
-			dots[Point{X: parsedLine.Numbers["X"], Y: parsedLine.Numbers["Y"]}] = struct{}{}
-		} else if instr, err := extractRegexp(foldFormat, line); err == nil {
-			if instr.Strings["Dim"] == "x" {
-				folds = append(folds, Fold{Dir: VERT, Val: instr.Numbers["Val"]})
-			} else {
-				folds = append(folds, Fold{Dir: HORIZ, Val: instr.Numbers["Val"]})
-			}
-		} else if len(line) == 0 {
-			//meh
-		} else {
-			log.Fatalf("couldn't parse line %d: %s", lineNo+1, line)
-		}
-
-		lineNo++
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+func (transparentOrigami) Part1(paper Paper) (Answer, error) {
+	foldMap, err := ComposeFolds(paper.Folds[:1], paper.Dots)
+	if err != nil {
+		return nil, err
 	}
+	return len(foldMap.ApplyAll(paper.Dots)), nil
+}
 
-	log.Printf("starting with %d dots", len(dots))
-
-	for _, fold := range folds {
-		newDots := make(map[Point]struct{})
-		for p := range dots {
-			if fold.Dir == VERT && p.X < fold.Val {
-				newDots[p] = struct{}{}
-				//				log.Printf("keeping %v for instruction %v", p, fold)
-			} else if fold.Dir == HORIZ && p.Y < fold.Val {
-				newDots[p] = struct{}{}
-				//				log.Printf("keeping %v for instruction %v", p, fold)
-			} else if fold.Dir == VERT && p.X > fold.Val {
-				distance := p.X - fold.Val
-				newPoint := Point{X: fold.Val - distance, Y: p.Y}
-				newDots[newPoint] = struct{}{}
-				//				log.Printf("flipping %v to %v for instruction %v", p, newPoint, fold)
-			} else if fold.Dir == HORIZ && p.Y > fold.Val {
-				distance := p.Y - fold.Val
-				newPoint := Point{X: p.X, Y: fold.Val - distance}
-				newDots[newPoint] = struct{}{}
-				//				log.Printf("flipping %v to %v for instruction %v", p, newPoint, fold)
-			} else {
-				//				log.Printf("point %v is on the line for instruction %v", p, fold)
-			}
-		}
-		dots = newDots
-		log.Printf("%d dots after instruction %v", len(dots), fold)
+func (transparentOrigami) Part2(paper Paper) (Answer, error) {
+	foldMap, err := ComposeFolds(paper.Folds, paper.Dots)
+	if err != nil {
+		return nil, err
 	}
-
-	log.Printf("%v", dots)
+	dots := foldMap.ApplyAll(paper.Dots)
+	log.Printf("%d dots after %d folds", len(dots), len(paper.Folds))
 
 	grid := [][]string{}
 	maxX := 0
@@ -285,5 +227,121 @@ func part2(input *scanner) string {
 		result = append(result, strings.Join(r, ""))
 	}
 
-	return strings.Join(result, "\n")
+	return strings.Join(result, "\n"), nil
+}
+
+// Shows every fold, before the answers are printed.
+func watch(paper Paper) (err error) {
+	foldMap, err := ComposeFolds(paper.Folds, paper.Dots)
+	if err != nil {
+		return err
+	}
+	screen, err := openScreen()
+	if err != nil || screen == nil {
+		return err
+	}
+	defer func() {
+		if cerr := screen.Close(); err == nil {
+			err = cerr
+		}
+		log.SetOutput(os.Stderr)
+	}()
+	if err := showFolds(screen, foldMap, paper.Dots, paper.Folds); err != ErrQuit {
+		return err
+	}
+	return nil
+}
+
+// Draws the finished paper to a PNG, or every fold to an animated GIF.
+func (transparentOrigami) Draw(paper Paper, path string) error {
+	foldMap, err := ComposeFolds(paper.Folds, paper.Dots)
+	if err != nil {
+		return err
+	}
+	return drawFolds(path, foldMap, paper.Dots, paper.Folds)
+}
+
+// Draws the paper after every fold, each frame the size of the unfolded sheet
+// so the animation doesn't jump around. A PNG only gets the finished paper,
+// cropped to its dots.
+func drawFolds(path string, full *FoldMap, dots map[Point]struct{}, folds []Fold) error {
+	const scale = 4
+	paper := color.Palette{color.RGBA{R: 245, G: 240, B: 225, A: 255}, color.RGBA{R: 30, G: 30, B: 60, A: 255}}
+	if !strings.EqualFold(filepath.Ext(path), ".gif") {
+		folded := []image.Point{}
+		for p := range full.ApplyAll(dots) {
+			folded = append(folded, image.Point{X: p.X, Y: p.Y})
+		}
+		return WritePNG(path, RenderPoints(folded, scale, paper[0], paper[1]))
+	}
+
+	recorder := &Recorder{Delay: 80}
+	for i := 0; i <= len(folds); i++ {
+		foldMap, err := ComposeFolds(folds[:i], dots)
+		if err != nil {
+			return err
+		}
+		folded := foldMap.ApplyAll(dots)
+		recorder.AddFrame(RenderGrid(full.Width, full.Height, 1, paper, func(x, y int) int {
+			if _, hit := folded[Point{X: x, Y: y}]; hit {
+				return 1
+			}
+			return 0
+		}))
+	}
+	return recorder.WriteGIF(path)
+}
+
+// Shows the paper after every fold, at the size it's folded down to. Until
+// it's small enough for the terminal, each cell covers a block of the paper
+// and is shaded by how many dots are in it.
+func showFolds(screen *Screen, full *FoldMap, dots map[Point]struct{}, folds []Fold) error {
+	density := append(color.Palette{color.RGBA{R: 245, G: 240, B: 225, A: 255}},
+		Ramp(color.RGBA{R: 150, G: 150, B: 190, A: 255}, color.RGBA{R: 30, G: 30, B: 60, A: 255}, 9)...)
+	width, height := full.Width, full.Height
+	for i := 0; i <= len(folds); i++ {
+		if i > 0 {
+			if folds[i-1].Dir == VERT {
+				width = folds[i-1].Val
+			} else {
+				height = folds[i-1].Val
+			}
+		}
+		foldMap, err := ComposeFolds(folds[:i], dots)
+		if err != nil {
+			return err
+		}
+		folded := foldMap.ApplyAll(dots)
+		w, h, cell := Downsample(width, height, 100, 40, func(x, y int) int {
+			if _, hit := folded[Point{X: x, Y: y}]; hit {
+				return 1
+			}
+			return 0
+		})
+		caption := fmt.Sprintf("%dx%d, %d dots", width, height, len(folded))
+		if i > 0 {
+			caption = fmt.Sprintf("fold %d along %v%d: %s", i, folds[i-1].Dir, folds[i-1].Val, caption)
+		}
+		if err := screen.Draw(w, h, density, cell, caption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prints where on the unfolded sheet a dot on the fully folded one could have
+// come from, and which of those places had dots.
+func traceOrigin(paper Paper, p Point) error {
+	foldMap, err := ComposeFolds(paper.Folds, paper.Dots)
+	if err != nil {
+		return err
+	}
+
+	preImages := foldMap.PreImages(p)
+	sources := foldMap.Sources(p, paper.Dots)
+	fmt.Printf("%d positions on the %dx%d sheet fold onto %d,%d; %d of them have dots:\n", len(preImages), foldMap.Width, foldMap.Height, p.X, p.Y, len(sources))
+	for _, s := range sources {
+		fmt.Printf("%d,%d\n", s.X, s.Y)
+	}
+	return nil
 }
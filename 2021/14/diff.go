@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// A template, its rules, and how many steps to run them for.
+type polymerCase struct {
+	Template string
+	Rules    map[string]byte
+	Steps    int
+}
+
+func (c polymerCase) String() string {
+	rules := []string{}
+	for pair, insert := range c.Rules {
+		rules = append(rules, fmt.Sprintf("%s -> %c", pair, insert))
+	}
+	sort.Strings(rules)
+	return fmt.Sprintf("%q with %d steps of {%s}", c.Template, c.Steps, strings.Join(rules, ", "))
+}
+
+func formatCounts(counts map[byte]int64) string {
+	elements := []string{}
+	for e, n := range counts {
+		elements = append(elements, fmt.Sprintf("%c:%d", e, n))
+	}
+	sort.Strings(elements)
+	return "{" + strings.Join(elements, " ") + "}"
+}
+
+func sameCounts(a, b map[byte]int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (c polymerCase) disagrees() (map[byte]int64, map[byte]int64, bool) {
+	built, counted := buildPolymer(c.Template, c.Rules, c.Steps), countPolymer(c.Template, c.Rules, c.Steps)
+	return built, counted, !sameCounts(built, counted)
+}
+
+func (c polymerCase) fails() bool {
+	_, _, bad := c.disagrees()
+	return bad
+}
+
+// Every case that's one step simpler: a step fewer, an element out of the
+// template (it's never left empty), or a rule fewer.
+func (c polymerCase) shrinks() []polymerCase {
+	shrinks := []polymerCase{}
+	if c.Steps > 0 {
+		shrinks = append(shrinks, polymerCase{Template: c.Template, Rules: c.Rules, Steps: c.Steps - 1})
+	}
+	for i := 0; len(c.Template) > 1 && i < len(c.Template); i++ {
+		shrinks = append(shrinks, polymerCase{Template: c.Template[:i] + c.Template[i+1:], Rules: c.Rules, Steps: c.Steps})
+	}
+	pairs := []string{}
+	for pair := range c.Rules {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	for _, drop := range pairs {
+		fewer := map[string]byte{}
+		for pair, insert := range c.Rules {
+			if pair != drop {
+				fewer[pair] = insert
+			}
+		}
+		shrinks = append(shrinks, polymerCase{Template: c.Template, Rules: fewer, Steps: c.Steps})
+	}
+	return shrinks
+}
+
+// Builds and counts polymers from random templates over a few elements, with
+// a random subset of all the possible rules, for every number of steps up to
+// maxSteps, and reports the simplest disagreement it can find, if any.
+func differential(rng *rand.Rand, runs int, maxSteps int) error {
+	for run := 0; run < runs; run++ {
+		elements := "ABCDE"[:2+rng.Intn(4)]
+		template := make([]byte, 1+rng.Intn(8))
+		for i := range template {
+			template[i] = elements[rng.Intn(len(elements))]
+		}
+		rules := map[string]byte{}
+		for _, a := range []byte(elements) {
+			for _, b := range []byte(elements) {
+				if rng.Intn(4) != 0 {
+					rules[string([]byte{a, b})] = elements[rng.Intn(len(elements))]
+				}
+			}
+		}
+
+		for steps := 0; steps <= maxSteps; steps++ {
+			c := polymerCase{Template: string(template), Rules: rules, Steps: steps}
+			if c.fails() {
+				c = minimize(c, polymerCase.shrinks, polymerCase.fails)
+				built, counted, _ := c.disagrees()
+				return fmt.Errorf("run %d: %v gives %s built but %s counted", run+1, c, formatCounts(built), formatCounts(counted))
+			}
+		}
+	}
+	return nil
+}
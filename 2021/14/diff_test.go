@@ -0,0 +1,39 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPolymerCounts(t *testing.T) {
+	rules := map[string]byte{
+		"CH": 'B', "HH": 'N', "CB": 'H', "NH": 'C', "HB": 'C', "HC": 'B', "HN": 'C', "NN": 'C',
+		"BH": 'H', "NC": 'B', "NB": 'B', "BN": 'B', "BB": 'N', "BC": 'B', "CC": 'N', "CN": 'C',
+	}
+	for _, tc := range []struct {
+		template string
+		rules    map[string]byte
+		steps    int
+		want     map[byte]int64
+	}{
+		{"NNCB", rules, 0, map[byte]int64{'N': 2, 'C': 1, 'B': 1}},
+		// NCNBCHB
+		{"NNCB", rules, 1, map[byte]int64{'N': 2, 'C': 2, 'B': 2, 'H': 1}},
+		{"NNCB", rules, 10, map[byte]int64{'B': 1749, 'C': 298, 'H': 161, 'N': 865}},
+		{"N", rules, 5, map[byte]int64{'N': 1}},
+		{"AB", nil, 3, map[byte]int64{'A': 1, 'B': 1}},
+	} {
+		if got := buildPolymer(tc.template, tc.rules, tc.steps); !sameCounts(got, tc.want) {
+			t.Errorf("buildPolymer(%q, %d steps) = %s, want %s", tc.template, tc.steps, formatCounts(got), formatCounts(tc.want))
+		}
+		if got := countPolymer(tc.template, tc.rules, tc.steps); !sameCounts(got, tc.want) {
+			t.Errorf("countPolymer(%q, %d steps) = %s, want %s", tc.template, tc.steps, formatCounts(got), formatCounts(tc.want))
+		}
+	}
+}
+
+func TestBuildingAgreesWithCounting(t *testing.T) {
+	if err := differential(rand.New(rand.NewSource(1)), 200, 8); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,132 +1,130 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
+	"os"
+	"strings"
 )
 
-func main() {
-	fmt.Printf("Part 1 solution: %d\n", part1())
+type scanner struct {
+	file *os.File
+	sc   *bufio.Scanner
+	err  error
+}
 
-	fmt.Printf("Part 2 solution: %d\n", part2())
+func (s *scanner) NextLine() (string, bool) {
+	if s.sc.Scan() {
+		return s.sc.Text(), true
+	} else {
+		return "", false
+	}
 }
 
-const (
-	start = "OOFNFCBHCKBBVNHBNVCP"
+func (s *scanner) Finish() error {
+	if s.sc != nil {
+		s.err = s.sc.Err()
+
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
+		s.sc = nil
+	}
+	return s.err
+}
+
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
+}
+
+var (
+	diffRuns  = flag.Int("diff", 0, "instead of solving, check building the polymer and counting pairs agree on this many random rule sets")
+	diffSeed  = flag.Int64("seed", 1, "random seed for -diff")
+	diffSteps = flag.Int("steps", 8, "-diff tries every number of steps up to this")
 )
 
-var mapping = map[string]byte{
-	"PH": 'V',
-	"OK": 'S',
-	"KK": 'O',
-	"BV": 'K',
-	"CV": 'S',
-	"SV": 'C',
-	"CK": 'O',
-	"PC": 'F',
-	"SC": 'O',
-	"KC": 'S',
-	"KF": 'N',
-	"SN": 'C',
-	"SF": 'P',
-	"OS": 'O',
-	"OP": 'N',
-	"FS": 'P',
-	"FV": 'N',
-	"CP": 'S',
-	"VS": 'P',
-	"PB": 'P',
-	"HP": 'P',
-	"PK": 'S',
-	"FC": 'F',
-	"SB": 'K',
-	"NC": 'V',
-	"PP": 'B',
-	"PN": 'N',
-	"VN": 'C',
-	"NV": 'O',
-	"OV": 'O',
-	"BS": 'K',
-	"FP": 'V',
-	"NK": 'K',
-	"PO": 'B',
-	"HF": 'H',
-	"VK": 'S',
-	"ON": 'C',
-	"KH": 'F',
-	"HO": 'P',
-	"OO": 'H',
-	"BC": 'V',
-	"CS": 'O',
-	"OC": 'B',
-	"VB": 'N',
-	"OF": 'P',
-	"FK": 'H',
-	"OH": 'H',
-	"CF": 'K',
-	"CC": 'V',
-	"BK": 'O',
-	"BH": 'F',
-	"VV": 'N',
-	"KS": 'V',
-	"FO": 'F',
-	"SH": 'F',
-	"OB": 'O',
-	"VH": 'F',
-	"HH": 'P',
-	"PF": 'C',
-	"NF": 'V',
-	"VP": 'S',
-	"CN": 'V',
-	"SK": 'O',
-	"FB": 'S',
-	"FN": 'S',
-	"BF": 'H',
-	"FF": 'V',
-	"CB": 'P',
-	"NN": 'O',
-	"VC": 'F',
-	"HK": 'F',
-	"BO": 'H',
-	"KO": 'C',
-	"CH": 'N',
-	"KP": 'C',
-	"HS": 'P',
-	"NP": 'O',
-	"NS": 'V',
-	"NB": 'H',
-	"HN": 'O',
-	"BP": 'C',
-	"VF": 'S',
-	"KN": 'P',
-	"HC": 'C',
-	"PS": 'K',
-	"BB": 'O',
-	"NO": 'N',
-	"NH": 'F',
-	"BN": 'F',
-	"KV": 'V',
-	"SS": 'K',
-	"CO": 'H',
-	"KB": 'P',
-	"FH": 'C',
-	"SP": 'C',
-	"SO": 'V',
-	"PV": 'S',
-	"VO": 'O',
-	"HV": 'N',
-	"HB": 'V',
+func main() {
+	flag.Parse()
+	if *diffRuns > 0 {
+		// the polymer is logged after every step
+		log.SetOutput(io.Discard)
+		if err := differential(rand.New(rand.NewSource(*diffSeed)), *diffRuns, *diffSteps); err != nil {
+			log.SetOutput(os.Stderr)
+			log.Fatal(err)
+		}
+		fmt.Printf("building and counting agreed on %d random rule sets\n", *diffRuns)
+		return
+	}
+
+	if err := RunSolver[Polymer](extendedPolymerization{}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// The polymer template, and the pair insertion rules: for a pair like "CH",
+// the element to put between them.
+type Polymer struct {
+	Template string
+	Rules    map[string]byte
+}
+
+type extendedPolymerization struct{}
+
+func (extendedPolymerization) Parse(input io.Reader) (Polymer, error) {
+	scanner := newScanner(input)
+	line, ok := scanner.NextLine()
+	if !ok {
+		if err := scanner.Finish(); err != nil {
+			return Polymer{}, err
+		}
+		return Polymer{}, fmt.Errorf("no polymer template")
+	}
+	polymer := Polymer{Template: strings.TrimSpace(line), Rules: map[string]byte{}}
+	if polymer.Template == "" {
+		return Polymer{}, fmt.Errorf("empty polymer template")
+	}
+	lineNo := 2
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			pair, insertion, found := strings.Cut(line, " -> ")
+			if !found || len(pair) != 2 || len(insertion) != 1 {
+				return Polymer{}, fmt.Errorf("line %d: expected a rule like \"CH -> B\", not %q", lineNo, line)
+			}
+			polymer.Rules[pair] = insertion[0]
+		}
+		lineNo++
+	}
+	if err := scanner.Finish(); err != nil {
+		return Polymer{}, err
+	}
+	return polymer, nil
+}
+
+func (extendedPolymerization) Part1(polymer Polymer) (Answer, error) {
+	return spread(buildPolymer(polymer.Template, polymer.Rules, 10)), nil
 }
 
-func part1() int {
-	theString := start
-	for step := 0; step < 10; step++ {
+func (extendedPolymerization) Part2(polymer Polymer) (Answer, error) {
+	return spread(countPolymer(polymer.Template, polymer.Rules, 40)), nil
+}
+
+// Builds the whole polymer, step by step, and counts each element in it. The
+// polymer roughly doubles in length every step.
+func buildPolymer(template string, rules map[string]byte, steps int) map[byte]int64 {
+	theString := template
+	for step := 0; step < steps; step++ {
 		newString := []byte{}
-		for i := 0; i < len(theString)-1; i++ {
-			if insertion, exists := mapping[theString[i:i+2]]; exists {
-				newString = append(newString, theString[i], insertion)
-			} else {
-				newString = append(newString, theString[i], theString[i+1])
+		pairs := Pairwise(FromSlice([]byte(theString)))
+		for p, ok := pairs.Next(); ok; p, ok = pairs.Next() {
+			newString = append(newString, p.Prev)
+			if insertion, exists := rules[string([]byte{p.Prev, p.Cur})]; exists {
+				newString = append(newString, insertion)
 			}
 		}
 		newString = append(newString, theString[len(theString)-1])
@@ -134,15 +132,19 @@ func part1() int {
 		log.Printf("after %d substitutions: %s", step+1, theString)
 	}
 
-	counts := map[rune]int{}
-	for _, char := range theString {
-		counts[char]++
+	counts := map[byte]int64{}
+	for i := range theString {
+		counts[theString[i]]++
 	}
+	return counts
+}
 
-	minCount := len(theString)
-	maxCount := 0
+// Most common element's count minus the least common's.
+func spread(counts map[byte]int64) int64 {
+	minCount := int64(-1)
+	maxCount := int64(0)
 	for _, c := range counts {
-		if c < minCount {
+		if c < minCount || minCount == -1 {
 			minCount = c
 		}
 		if c > maxCount {
@@ -153,7 +155,8 @@ func part1() int {
 	return maxCount - minCount
 }
 
-func part2() int64 {
+// The same counts as buildPolymer, without building the polymer.
+func countPolymer(template string, rules map[string]byte, steps int) map[byte]int64 {
 	// Similar to day 6 (the puzzle with the reproducing lanternfish), we don't
 	// actually care /where/ each character is, just how many of each
 	// subpattern there are. Therefore, we can handle them in bulk; this
@@ -163,22 +166,23 @@ func part2() int64 {
 	// count of each character currently in the string. used to compute final
 	// output.
 	counts := map[byte]int64{}
-	for i := range start {
-		counts[start[i]]++
+	for i := range template {
+		counts[template[i]]++
 	}
 
 	// number of each /pair/ of adjacent characters currently in the string.
 	// used to do updates in each generation.
 	bigrams := map[string]int64{}
-	for i := 0; i < len(start)-1; i++ {
-		bigrams[string(start[i:i+2])]++
+	pairs := Pairwise(FromSlice([]byte(template)))
+	for p, ok := pairs.Next(); ok; p, ok = pairs.Next() {
+		bigrams[string([]byte{p.Prev, p.Cur})]++
 	}
 	log.Printf("starting bigrams: %v", bigrams)
 
-	for step := 0; step < 40; step++ {
+	for step := 0; step < steps; step++ {
 		newBigrams := map[string]int64{}
 		for bigram, count := range bigrams {
-			if insertion, exists := mapping[bigram]; exists {
+			if insertion, exists := rules[bigram]; exists {
 				// count the char we're adding
 				counts[insertion] += count
 
@@ -199,17 +203,5 @@ func part2() int64 {
 	}
 
 	log.Printf("ending bigrams: %v", bigrams)
-
-	minCount := int64(-1)
-	maxCount := int64(0)
-	for _, c := range counts {
-		if c < minCount || minCount == -1 {
-			minCount = c
-		}
-		if c > maxCount {
-			maxCount = c
-		}
-	}
-
-	return maxCount - minCount
+	return counts
 }
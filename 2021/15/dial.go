@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+// Risk of entering any point is 0 to 9, so while the search is at distance d
+// every point still waiting is at most d+9 away. Ten buckets, reused round
+// and round, are enough to hold them all in order.
+const dialBuckets = 10
+
+// The same answer as distanceToPoint, using Dijkstra's algorithm with a
+// bucket queue (Dial's algorithm) instead of a heap, and slices indexed by
+// cell instead of maps.
+func dialPath(m RiskMap, target Point) (int, []Point) {
+	width := target.col + 1
+	cells := width * (target.row + 1)
+	point := func(i int) Point { return Point{row: i / width, col: i % width} }
+
+	dist := make([]int, cells)
+	prev := make([]int, cells)
+	risk := make([]int8, cells)
+	for i := range dist {
+		dist[i] = -1
+		prev[i] = -1
+		risk[i] = int8(m.Risk(point(i)))
+	}
+
+	buckets := make([][]int, dialBuckets)
+	dist[0] = 0
+	buckets[0] = append(buckets[0], 0)
+	waiting := 1
+	for d := 0; waiting > 0; d++ {
+		b := d % dialBuckets
+		// a point reached through a risk 0 point lands back in this bucket
+		// while it's being emptied, so it has to be read as it grows
+		for j := 0; j < len(buckets[b]); j++ {
+			i := buckets[b][j]
+			waiting--
+			if dist[i] != d {
+				// already reached more cheaply, and dealt with then
+				continue
+			}
+			if i == cells-1 {
+				return d, dialRoute(prev, i, point)
+			}
+			row, col := i/width, i%width
+			// in a single row or column the neighbours' indexes coincide, so
+			// each one says for itself whether it's on the map
+			for _, step := range [4]struct {
+				n  int
+				ok bool
+			}{
+				{i - width, row > 0},
+				{i + width, row < target.row},
+				{i - 1, col > 0},
+				{i + 1, col < target.col},
+			} {
+				if !step.ok {
+					continue
+				}
+				n := step.n
+				if nd := d + int(risk[n]); dist[n] == -1 || nd < dist[n] {
+					dist[n] = nd
+					prev[n] = i
+					buckets[nd%dialBuckets] = append(buckets[nd%dialBuckets], n)
+					waiting++
+				}
+			}
+		}
+		buckets[b] = buckets[b][:0]
+	}
+
+	return -1, nil
+}
+
+func dialRoute(prev []int, i int, point func(int) Point) []Point {
+	route := []Point{}
+	for ; i != -1; i = prev[i] {
+		route = append(route, point(i))
+	}
+	for a, b := 0, len(route)-1; a < b; a, b = a+1, b-1 {
+		route[a], route[b] = route[b], route[a]
+	}
+	return route
+}
+
+// Runs every search n times to the target and prints how long each took on
+// average, and how much faster each is than A*. The heap search logs every
+// improvement it finds, so logging is turned off while timing.
+func benchmarkSearches(m RiskMap, target Point, n int) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	names := make([]string, 0, len(searches))
+	for name := range searches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	perRun := make(map[string]time.Duration)
+	answer := -1
+	for _, name := range names {
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			risk, route := searches[name](m, target)
+			if answer == -1 {
+				answer = risk
+			}
+			if risk != answer || routeRisk(m, route) != risk {
+				fmt.Printf("%s disagrees: risk %d, route costs %d, expected %d\n", name, risk, routeRisk(m, route), answer)
+			}
+		}
+		perRun[name] = time.Since(start) / time.Duration(n)
+	}
+	for _, name := range names {
+		fmt.Printf("%-6s %12v/search  %6.1fx\n", name, perRun[name], float64(perRun["astar"])/float64(perRun[name]))
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+// The lowest total risk to target by relaxing every point until nothing
+// improves: slow, but too simple to get wrong.
+func relaxedRisk(m RiskMap, target Point) int {
+	best := map[Point]int{{}: 0}
+	for changed := true; changed; {
+		changed = false
+		for r := 0; r <= target.row; r++ {
+			for c := 0; c <= target.col; c++ {
+				p := Point{row: r, col: c}
+				for _, n := range []Point{{row: r - 1, col: c}, {row: r + 1, col: c}, {row: r, col: c - 1}, {row: r, col: c + 1}} {
+					cost, reached := best[n]
+					if !reached {
+						continue
+					}
+					if old, ok := best[p]; !ok || cost+m.Risk(p) < old {
+						best[p] = cost + m.Risk(p)
+						changed = true
+					}
+				}
+			}
+		}
+	}
+	return best[target]
+}
+
+func parseMap(t testing.TB, text string) RiskMap {
+	t.Helper()
+	m, err := chiton{}.Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// A point reached through a risk 0 point costs no more than that point, so it
+// has to be settled from the bucket being emptied; the totals must still come
+// out the same as the heap search's, and match the routes returned.
+func TestDialZeroRisk(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	m := parseMap(t, "10\n09\n")
+	for _, tc := range []struct {
+		target Point
+		want   int
+	}{
+		{m.Tile(), 9},
+		{m.Full(), 74},
+	} {
+		risk, route := dialPath(m, tc.target)
+		if risk != tc.want {
+			t.Errorf("dialPath to %v = %d, want %d", tc.target, risk, tc.want)
+		}
+		if got := routeRisk(m, route); got != risk {
+			t.Errorf("route to %v costs %d, but dialPath said %d", tc.target, got, risk)
+		}
+		if heap, _ := distanceToPoint(m, tc.target); heap != risk {
+			t.Errorf("dialPath to %v = %d, but the heap search says %d", tc.target, risk, heap)
+		}
+	}
+}
+
+func TestDialMatchesRelaxation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		var sb strings.Builder
+		lo := rng.Intn(2)
+		if err := generateDigitGrid(&sb, rng, 1+rng.Intn(6), 1+rng.Intn(6), lo, 9); err != nil {
+			t.Fatal(err)
+		}
+		m := parseMap(t, sb.String())
+		for _, target := range []Point{m.Tile(), m.Full()} {
+			want := relaxedRisk(m, target)
+			risk, route := dialPath(m, target)
+			if len(route) == 0 {
+				t.Fatalf("map\n%s\nto %v: dialPath says %d, with no route", sb.String(), target, risk)
+			}
+			if risk != want || routeRisk(m, route) != want {
+				t.Errorf("map\n%s\nto %v: dialPath says %d (route costs %d), want %d", sb.String(), target, risk, routeRisk(m, route), want)
+			}
+		}
+	}
+}
+
+func benchmarkSearch(b *testing.B, search func(RiskMap, Point) (int, []Point)) {
+	f, err := os.Open("input.txt")
+	if err != nil {
+		b.Skip(err)
+	}
+	defer f.Close()
+	m, err := chiton{}.Parse(f)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// the heap search logs every improvement it finds
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		search(m, m.Full())
+	}
+}
+
+func BenchmarkDial(b *testing.B)  { benchmarkSearch(b, dialPath) }
+func BenchmarkAStar(b *testing.B) { benchmarkSearch(b, distanceToPoint) }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+)
+
+// Follows the steps back from p to wherever the search started, and returns
+// them in the order they're taken, both ends included.
+func routeTo(cameFrom map[Point]Point, p Point) []Point {
+	route := []Point{p}
+	for {
+		prev, ok := cameFrom[p]
+		if !ok {
+			break
+		}
+		route = append(route, prev)
+		p = prev
+	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route
+}
+
+// What it costs to follow the route; the start is free.
+func routeRisk(m RiskMap, route []Point) int {
+	total := 0
+	for _, p := range route[1:] {
+		total += m.Risk(p)
+	}
+	return total
+}
+
+// The map from (0, 0) to corner as text, with the risk of each point on the
+// route and a dot everywhere else.
+func routeOverlay(m RiskMap, corner Point, route []Point) []string {
+	onRoute := make(map[Point]bool, len(route))
+	for _, p := range route {
+		onRoute[p] = true
+	}
+	rows := make([]string, 0, corner.row+1)
+	for r := 0; r <= corner.row; r++ {
+		row := make([]byte, corner.col+1)
+		for c := range row {
+			if p := (Point{row: r, col: c}); onRoute[p] {
+				row[c] = byte('0' + m.Risk(p))
+			} else {
+				row[c] = '.'
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return rows
+}
+
+func writeRoutes(path string, m RiskMap, routes ...[]Point) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	out := bufio.NewWriter(f)
+	for i, route := range routes {
+		if len(route) == 0 {
+			continue
+		}
+		corner := route[len(route)-1]
+		fmt.Fprintf(out, "Part %d: %d steps to %d,%d, risk %d\n", i+1, len(route)-1, corner.col, corner.row, routeRisk(m, route))
+		for _, row := range routeOverlay(m, corner, route) {
+			fmt.Fprintln(out, row)
+		}
+		fmt.Fprintln(out)
+	}
+	if err := out.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Low risk is dark, high risk is bright. After those come a color for each
+// route, and one for where they share points.
+var riskPalette = append(
+	Ramp(color.RGBA{R: 20, G: 30, B: 20, A: 255}, color.RGBA{R: 230, G: 240, B: 120, A: 255}, 9),
+	color.RGBA{R: 230, G: 40, B: 40, A: 255},
+	color.RGBA{R: 40, G: 120, B: 255, A: 255},
+	color.RGBA{R: 255, G: 255, B: 255, A: 255},
+)
+
+// Draws every point from (0, 0) to corner, shaded by its risk, with up to two
+// routes over the top.
+func renderRisk(m RiskMap, corner Point, routes ...[]Point) *image.Paletted {
+	onRoute := make(map[Point]int)
+	for i, route := range routes {
+		for _, p := range route {
+			onRoute[p] |= 1 << i
+		}
+	}
+	return RenderGrid(corner.col+1, corner.row+1, 2, riskPalette, func(x, y int) int {
+		p := Point{row: y, col: x}
+		if which := onRoute[p]; which != 0 {
+			return 8 + which
+		}
+		return m.Risk(p) - 1
+	})
+}
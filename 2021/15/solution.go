@@ -2,129 +2,123 @@ package main
 
 import (
 	"container/heap"
-	"fmt"
+	"flag"
+	"io"
 	"log"
+	"math/rand"
+	"os"
 )
 
+var (
+	routePath = flag.String("route", "", "write both parts' routes over their maps as text to this file")
+	search    = flag.String("search", "dial", "how to find the routes: dial (bucket queue) or astar (heap)")
+	benchRuns = flag.Int("bench", 0, "instead of solving, time this many part 2 searches with each algorithm")
+	generate  = flag.Bool("generate", false, "instead of solving, print a random risk map to use as input")
+	seed      = flag.Int64("seed", 1, "random seed for -generate")
+	width     = flag.Int("width", 100, "how wide a map -generate makes")
+	height    = flag.Int("height", 100, "how tall a map -generate makes")
+)
+
+var searches = map[string]func(m RiskMap, target Point) (int, []Point){
+	"dial":  dialPath,
+	"astar": distanceToPoint,
+}
+
 func main() {
-	fmt.Printf("Part 1 solution: %d\n", distanceToPoint(Point{row: 99, col: 99}))
+	flag.Parse()
+	if *generate {
+		if err := generateDigitGrid(os.Stdout, rand.New(rand.NewSource(*seed)), *width, *height, 1, 9); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	shortestPath, ok := searches[*search]
+	if !ok {
+		log.Fatalf("unknown search %q", *search)
+	}
+	solver := chiton{shortestPath: shortestPath, routes: &[2][]Point{}}
 
-	fmt.Printf("Part 2 solution: %d\n", distanceToPoint(Point{row: 499, col: 499}))
+	if *benchRuns > 0 {
+		m, err := readInput[RiskMap](solver)
+		if err != nil {
+			log.Fatal(err)
+		}
+		benchmarkSearches(m, m.Full(), *benchRuns)
+		return
+	}
+	if err := RunSolver[RiskMap](solver); err != nil {
+		log.Fatal(err)
+	}
 }
 
-var (
-	input = [][]int{
-		{9, 9, 9, 6, 5, 9, 7, 1, 6, 9, 9, 3, 4, 4, 6, 2, 8, 6, 6, 6, 7, 4, 2, 9, 8, 3, 1, 1, 5, 8, 5, 4, 6, 2, 1, 6, 4, 9, 3, 8, 4, 2, 9, 5, 9, 5, 3, 2, 5, 8, 9, 8, 9, 8, 2, 4, 4, 6, 7, 5, 6, 7, 7, 8, 9, 2, 6, 6, 2, 3, 3, 1, 2, 4, 1, 9, 9, 2, 8, 4, 6, 2, 5, 8, 1, 9, 5, 4, 9, 5, 9, 6, 8, 2, 6, 9, 1, 9, 6, 7},
-		{2, 9, 8, 5, 4, 7, 3, 8, 2, 1, 1, 9, 2, 2, 5, 1, 9, 7, 4, 9, 5, 1, 8, 5, 7, 5, 9, 9, 2, 6, 7, 1, 2, 9, 5, 8, 1, 7, 2, 2, 1, 9, 3, 9, 8, 4, 6, 6, 9, 4, 9, 9, 1, 1, 1, 8, 9, 1, 1, 4, 8, 2, 9, 9, 4, 1, 7, 3, 6, 7, 9, 1, 8, 1, 9, 4, 7, 7, 7, 3, 6, 9, 2, 9, 2, 3, 2, 3, 5, 7, 7, 9, 1, 7, 2, 7, 1, 6, 8, 9},
-		{9, 9, 2, 5, 7, 4, 7, 2, 1, 9, 5, 6, 5, 2, 1, 8, 2, 9, 2, 7, 9, 1, 8, 7, 8, 8, 8, 4, 8, 6, 7, 8, 7, 1, 9, 1, 2, 8, 1, 2, 8, 8, 8, 4, 3, 8, 4, 1, 9, 9, 1, 3, 9, 5, 1, 8, 3, 8, 8, 8, 4, 6, 2, 9, 8, 6, 1, 1, 7, 4, 6, 9, 2, 1, 9, 4, 1, 2, 7, 2, 9, 9, 5, 5, 7, 9, 9, 7, 7, 1, 6, 2, 9, 2, 4, 1, 9, 8, 9, 9},
-		{5, 6, 8, 9, 3, 9, 4, 2, 5, 7, 3, 9, 7, 9, 2, 4, 9, 6, 8, 6, 1, 5, 9, 8, 6, 1, 9, 4, 5, 7, 5, 9, 6, 7, 9, 3, 9, 8, 2, 9, 4, 9, 4, 5, 3, 6, 3, 1, 5, 7, 9, 1, 8, 9, 1, 1, 8, 9, 3, 9, 9, 3, 4, 6, 1, 9, 8, 3, 5, 4, 7, 9, 7, 9, 7, 1, 4, 5, 5, 4, 8, 2, 8, 9, 6, 4, 7, 8, 4, 7, 8, 9, 9, 1, 9, 1, 5, 8, 1, 9},
-		{7, 1, 9, 1, 7, 1, 4, 2, 3, 1, 6, 1, 4, 9, 1, 4, 2, 5, 8, 8, 4, 3, 6, 9, 8, 9, 3, 5, 9, 2, 8, 4, 6, 9, 8, 2, 8, 9, 4, 7, 2, 6, 1, 2, 5, 3, 7, 7, 8, 8, 7, 4, 5, 9, 6, 5, 4, 9, 3, 1, 7, 1, 7, 9, 3, 1, 7, 7, 3, 9, 7, 6, 9, 2, 1, 1, 8, 1, 5, 9, 4, 1, 3, 9, 3, 1, 7, 8, 9, 9, 3, 2, 3, 5, 4, 8, 3, 1, 6, 4},
-		{8, 2, 6, 1, 4, 1, 1, 3, 1, 1, 8, 5, 8, 6, 2, 5, 5, 2, 5, 1, 8, 9, 1, 4, 1, 9, 2, 7, 6, 3, 4, 5, 8, 4, 9, 5, 7, 9, 6, 9, 1, 9, 3, 9, 9, 8, 5, 9, 4, 5, 8, 9, 8, 3, 1, 1, 6, 1, 8, 2, 7, 4, 8, 6, 4, 2, 8, 1, 8, 1, 8, 2, 2, 4, 9, 2, 3, 4, 1, 1, 4, 8, 9, 3, 3, 9, 1, 6, 6, 9, 9, 7, 3, 5, 9, 1, 1, 9, 5, 1},
-		{8, 4, 9, 1, 9, 1, 6, 3, 3, 8, 9, 5, 7, 6, 1, 4, 8, 7, 9, 9, 4, 7, 8, 2, 7, 9, 1, 8, 2, 7, 1, 9, 1, 9, 2, 9, 2, 1, 1, 5, 2, 4, 9, 6, 6, 9, 5, 2, 8, 7, 5, 2, 1, 3, 7, 6, 2, 6, 9, 9, 9, 2, 8, 9, 8, 7, 8, 3, 2, 1, 7, 1, 8, 9, 9, 5, 1, 7, 4, 4, 7, 1, 1, 5, 2, 8, 5, 3, 2, 5, 6, 5, 3, 4, 9, 9, 8, 8, 6, 2},
-		{2, 8, 7, 8, 9, 4, 2, 9, 7, 9, 6, 8, 9, 9, 3, 2, 5, 1, 8, 7, 8, 8, 9, 9, 3, 6, 9, 3, 9, 1, 9, 2, 3, 2, 9, 9, 4, 1, 8, 1, 2, 9, 5, 1, 4, 1, 9, 9, 8, 6, 7, 4, 7, 8, 6, 2, 2, 9, 4, 9, 3, 4, 4, 4, 8, 9, 3, 5, 2, 9, 9, 2, 1, 3, 9, 6, 2, 5, 1, 9, 1, 6, 7, 9, 2, 8, 6, 9, 7, 7, 8, 3, 6, 9, 7, 6, 1, 5, 7, 7},
-		{5, 3, 2, 4, 2, 9, 9, 1, 7, 9, 2, 9, 3, 7, 8, 9, 2, 7, 8, 8, 9, 6, 3, 5, 2, 9, 9, 9, 5, 2, 1, 1, 6, 1, 1, 9, 6, 3, 3, 5, 6, 7, 5, 6, 6, 8, 4, 9, 4, 8, 9, 8, 1, 6, 3, 7, 2, 3, 5, 1, 9, 1, 8, 9, 3, 5, 4, 5, 1, 9, 9, 1, 5, 5, 7, 2, 9, 1, 1, 9, 2, 4, 8, 1, 7, 1, 4, 8, 6, 9, 7, 9, 7, 2, 8, 8, 4, 5, 9, 5},
-		{3, 2, 4, 9, 2, 8, 6, 9, 8, 4, 6, 6, 4, 7, 6, 2, 8, 8, 8, 9, 6, 4, 5, 1, 9, 9, 2, 7, 1, 4, 7, 3, 8, 8, 3, 3, 1, 1, 9, 2, 9, 6, 2, 9, 7, 1, 4, 4, 7, 9, 9, 8, 5, 6, 9, 5, 8, 8, 8, 1, 1, 8, 9, 8, 8, 8, 7, 9, 3, 8, 9, 1, 6, 9, 1, 2, 8, 7, 6, 8, 3, 8, 8, 4, 5, 2, 2, 6, 8, 9, 1, 9, 1, 7, 6, 9, 4, 3, 9, 6},
-		{8, 3, 8, 4, 7, 1, 1, 5, 8, 5, 5, 8, 9, 9, 3, 2, 8, 1, 7, 2, 1, 7, 1, 9, 7, 2, 6, 6, 2, 3, 4, 4, 4, 8, 8, 6, 4, 9, 8, 6, 2, 6, 7, 8, 8, 7, 7, 3, 1, 4, 5, 1, 2, 6, 6, 5, 5, 1, 8, 1, 9, 5, 4, 6, 6, 7, 9, 4, 9, 3, 9, 8, 5, 4, 8, 9, 2, 7, 1, 8, 8, 7, 9, 5, 9, 1, 9, 2, 7, 1, 5, 9, 9, 4, 6, 1, 7, 1, 1, 8},
-		{8, 5, 5, 8, 3, 9, 2, 9, 8, 8, 6, 4, 5, 9, 2, 6, 4, 9, 7, 6, 6, 1, 2, 2, 1, 5, 6, 1, 1, 9, 9, 1, 8, 7, 5, 9, 1, 6, 1, 4, 9, 7, 1, 8, 6, 2, 6, 9, 3, 4, 1, 8, 2, 5, 9, 7, 4, 9, 6, 8, 2, 9, 9, 5, 9, 1, 7, 7, 9, 9, 1, 1, 1, 3, 7, 5, 9, 9, 7, 9, 1, 1, 4, 9, 1, 3, 1, 4, 6, 1, 8, 5, 6, 3, 9, 5, 5, 2, 8, 3},
-		{8, 4, 4, 8, 9, 8, 2, 1, 3, 3, 9, 8, 1, 1, 8, 6, 8, 9, 9, 3, 2, 8, 3, 3, 8, 2, 1, 9, 9, 1, 2, 6, 1, 4, 1, 7, 5, 9, 2, 9, 6, 9, 6, 8, 6, 5, 4, 1, 1, 1, 4, 1, 9, 3, 6, 9, 4, 4, 3, 4, 9, 2, 9, 3, 2, 1, 8, 7, 9, 2, 9, 7, 8, 1, 5, 8, 6, 9, 7, 2, 3, 8, 9, 8, 9, 1, 4, 9, 4, 8, 1, 7, 9, 9, 7, 8, 7, 6, 9, 3},
-		{6, 8, 2, 9, 7, 8, 8, 5, 4, 9, 5, 3, 8, 1, 7, 2, 9, 1, 5, 2, 1, 2, 8, 6, 1, 7, 6, 1, 7, 6, 2, 8, 1, 1, 4, 1, 1, 9, 9, 9, 4, 4, 6, 2, 1, 2, 9, 8, 7, 9, 5, 7, 9, 9, 9, 8, 1, 8, 8, 9, 8, 7, 6, 9, 1, 2, 9, 9, 9, 5, 7, 1, 6, 6, 6, 8, 1, 8, 9, 7, 2, 9, 1, 5, 3, 2, 8, 1, 2, 7, 8, 9, 9, 2, 8, 8, 2, 9, 6, 1},
-		{8, 1, 7, 1, 8, 5, 3, 9, 3, 5, 9, 9, 6, 9, 9, 9, 7, 5, 9, 8, 2, 7, 7, 8, 5, 2, 7, 4, 1, 1, 9, 3, 6, 9, 8, 1, 9, 4, 7, 4, 1, 1, 6, 3, 2, 9, 2, 2, 1, 9, 1, 2, 1, 1, 7, 8, 8, 7, 9, 9, 2, 3, 8, 6, 9, 7, 9, 9, 9, 9, 9, 3, 1, 9, 2, 4, 6, 4, 2, 7, 8, 6, 1, 6, 2, 3, 4, 6, 8, 3, 8, 9, 8, 8, 8, 9, 6, 9, 9, 8},
-		{1, 6, 1, 1, 2, 5, 9, 7, 9, 6, 7, 3, 2, 3, 6, 5, 6, 8, 3, 9, 9, 7, 9, 1, 1, 7, 2, 8, 8, 5, 3, 1, 1, 5, 9, 7, 8, 7, 2, 4, 2, 8, 1, 2, 8, 5, 9, 9, 8, 8, 7, 8, 7, 2, 9, 4, 8, 7, 1, 7, 2, 9, 3, 7, 9, 9, 6, 3, 8, 1, 1, 1, 1, 8, 9, 3, 3, 6, 8, 4, 2, 7, 1, 9, 9, 7, 8, 8, 9, 9, 5, 5, 2, 9, 6, 9, 9, 9, 1, 5},
-		{5, 6, 8, 8, 5, 1, 2, 9, 8, 1, 1, 2, 8, 4, 6, 1, 9, 3, 7, 1, 9, 2, 3, 1, 2, 7, 6, 1, 9, 4, 5, 1, 5, 9, 1, 4, 8, 3, 9, 1, 9, 6, 8, 9, 7, 6, 3, 5, 5, 9, 7, 9, 9, 2, 1, 6, 9, 1, 4, 9, 1, 7, 7, 1, 7, 9, 9, 2, 2, 6, 8, 9, 3, 9, 9, 7, 1, 9, 8, 1, 9, 4, 7, 5, 9, 8, 9, 6, 7, 9, 9, 9, 5, 4, 9, 4, 5, 3, 9, 9},
-		{4, 6, 7, 7, 3, 3, 1, 8, 8, 7, 6, 8, 8, 1, 1, 9, 6, 9, 7, 9, 4, 9, 5, 1, 1, 9, 9, 6, 7, 2, 3, 7, 9, 1, 9, 9, 9, 2, 9, 4, 2, 1, 9, 2, 2, 5, 4, 6, 2, 6, 2, 8, 9, 3, 8, 7, 6, 8, 4, 4, 6, 4, 9, 1, 2, 9, 6, 1, 9, 1, 8, 3, 2, 9, 8, 3, 9, 8, 1, 8, 1, 9, 2, 9, 2, 8, 1, 4, 1, 4, 1, 7, 7, 1, 7, 4, 3, 7, 1, 3},
-		{6, 9, 9, 8, 4, 8, 3, 9, 3, 1, 4, 1, 7, 2, 5, 1, 9, 2, 9, 1, 9, 3, 2, 6, 8, 8, 1, 9, 8, 9, 2, 1, 1, 8, 7, 6, 6, 1, 2, 8, 6, 6, 4, 9, 7, 9, 7, 9, 8, 9, 6, 3, 4, 6, 7, 9, 9, 1, 9, 8, 4, 1, 8, 4, 9, 8, 7, 1, 7, 9, 5, 7, 9, 5, 1, 4, 7, 9, 5, 9, 8, 1, 9, 3, 8, 4, 4, 8, 2, 9, 6, 8, 9, 7, 6, 1, 8, 5, 1, 7},
-		{1, 3, 1, 9, 6, 9, 1, 9, 8, 9, 7, 3, 3, 1, 9, 9, 9, 7, 3, 5, 2, 8, 3, 8, 8, 4, 2, 7, 1, 6, 2, 2, 7, 3, 1, 9, 3, 3, 7, 2, 4, 7, 5, 9, 2, 2, 1, 1, 9, 7, 7, 9, 9, 2, 1, 8, 9, 2, 9, 5, 4, 9, 3, 4, 9, 8, 5, 5, 9, 8, 3, 9, 8, 8, 8, 6, 5, 1, 5, 9, 9, 7, 1, 8, 1, 9, 5, 1, 3, 3, 8, 3, 6, 5, 9, 6, 9, 4, 8, 1},
-		{4, 2, 9, 9, 5, 2, 7, 9, 1, 9, 5, 9, 8, 8, 3, 6, 7, 1, 2, 3, 9, 6, 1, 6, 6, 7, 8, 4, 9, 1, 2, 9, 8, 9, 5, 3, 8, 4, 9, 5, 9, 5, 8, 1, 9, 9, 8, 9, 9, 8, 1, 9, 5, 9, 7, 1, 6, 8, 4, 2, 9, 2, 9, 1, 9, 9, 5, 7, 9, 7, 3, 9, 5, 5, 1, 2, 3, 9, 2, 5, 5, 5, 9, 1, 3, 6, 7, 8, 8, 9, 5, 9, 9, 4, 6, 3, 9, 4, 9, 8},
-		{1, 9, 5, 9, 9, 4, 3, 1, 6, 2, 2, 6, 7, 3, 5, 7, 3, 1, 2, 7, 8, 9, 4, 8, 9, 2, 6, 1, 8, 1, 2, 2, 3, 9, 9, 2, 5, 9, 6, 4, 6, 8, 9, 1, 2, 1, 7, 6, 2, 2, 2, 1, 7, 8, 7, 6, 6, 7, 8, 8, 5, 6, 2, 5, 8, 7, 8, 7, 4, 4, 2, 1, 4, 4, 9, 7, 9, 8, 9, 6, 6, 9, 1, 4, 5, 3, 2, 2, 9, 7, 6, 4, 8, 1, 7, 9, 7, 4, 8, 9},
-		{2, 3, 9, 5, 1, 8, 6, 8, 5, 1, 9, 4, 9, 5, 9, 7, 5, 1, 9, 5, 2, 1, 9, 5, 9, 9, 9, 9, 9, 4, 6, 5, 5, 6, 2, 1, 1, 1, 5, 9, 6, 1, 3, 2, 7, 1, 1, 6, 1, 9, 9, 3, 8, 1, 8, 6, 4, 2, 2, 2, 9, 6, 9, 3, 3, 9, 1, 1, 5, 5, 1, 8, 7, 1, 9, 9, 8, 9, 8, 7, 8, 1, 1, 4, 4, 1, 9, 4, 6, 9, 9, 8, 5, 2, 8, 9, 6, 6, 5, 2},
-		{8, 9, 9, 5, 8, 8, 1, 7, 3, 4, 1, 2, 9, 8, 6, 9, 4, 6, 9, 4, 9, 4, 2, 3, 3, 2, 1, 3, 7, 5, 8, 9, 8, 5, 5, 6, 6, 8, 7, 6, 1, 2, 2, 3, 4, 1, 9, 5, 5, 9, 1, 9, 4, 1, 9, 8, 3, 7, 1, 9, 2, 2, 6, 9, 7, 2, 4, 1, 8, 4, 2, 9, 7, 9, 9, 9, 1, 9, 1, 7, 8, 9, 9, 1, 5, 3, 9, 7, 1, 6, 1, 1, 7, 5, 6, 9, 4, 5, 1, 4},
-		{1, 5, 8, 9, 9, 8, 9, 1, 9, 8, 7, 4, 4, 7, 8, 1, 3, 2, 9, 4, 1, 9, 5, 9, 9, 1, 9, 9, 5, 1, 1, 6, 8, 6, 4, 2, 5, 4, 6, 8, 5, 9, 6, 9, 7, 8, 4, 7, 5, 9, 1, 1, 1, 3, 2, 7, 2, 6, 9, 5, 9, 4, 2, 9, 9, 3, 6, 1, 7, 2, 1, 9, 3, 2, 3, 9, 6, 9, 3, 3, 1, 6, 8, 6, 8, 6, 2, 5, 9, 6, 5, 9, 1, 6, 1, 8, 4, 8, 3, 9},
-		{9, 8, 8, 2, 4, 4, 7, 9, 9, 3, 2, 1, 4, 9, 6, 4, 2, 8, 2, 1, 2, 1, 9, 3, 2, 2, 1, 9, 2, 3, 2, 5, 7, 4, 6, 2, 9, 5, 4, 9, 3, 9, 1, 6, 9, 1, 9, 2, 9, 1, 3, 3, 2, 6, 8, 9, 9, 4, 1, 4, 2, 9, 9, 8, 1, 9, 8, 1, 4, 7, 3, 4, 2, 6, 3, 6, 3, 9, 6, 8, 8, 1, 7, 4, 5, 9, 8, 1, 9, 9, 8, 9, 9, 3, 4, 7, 4, 9, 1, 2},
-		{7, 7, 3, 7, 8, 7, 6, 9, 2, 9, 1, 2, 8, 2, 1, 9, 4, 5, 9, 5, 2, 8, 6, 4, 5, 8, 1, 1, 5, 3, 9, 3, 3, 9, 9, 9, 6, 6, 1, 8, 3, 9, 9, 9, 7, 2, 9, 6, 1, 9, 8, 1, 2, 9, 5, 8, 4, 8, 2, 9, 7, 6, 2, 1, 9, 9, 6, 9, 4, 4, 5, 9, 1, 6, 9, 9, 8, 9, 1, 6, 8, 2, 6, 7, 1, 6, 7, 6, 8, 8, 9, 9, 9, 3, 2, 4, 5, 3, 4, 1},
-		{2, 1, 5, 8, 4, 3, 9, 7, 2, 5, 3, 7, 7, 3, 1, 7, 7, 5, 8, 9, 3, 9, 7, 1, 8, 7, 6, 8, 3, 7, 4, 2, 4, 7, 1, 6, 6, 5, 9, 2, 1, 9, 9, 4, 1, 7, 3, 9, 9, 8, 8, 9, 6, 4, 1, 4, 2, 7, 2, 9, 1, 8, 9, 6, 8, 8, 6, 2, 2, 6, 6, 9, 3, 8, 9, 8, 8, 1, 7, 6, 9, 7, 7, 8, 2, 9, 7, 3, 9, 9, 8, 9, 5, 1, 1, 5, 5, 2, 9, 7},
-		{9, 8, 8, 7, 4, 3, 5, 6, 9, 4, 6, 9, 7, 3, 1, 9, 5, 8, 3, 4, 1, 9, 9, 1, 4, 9, 3, 4, 7, 4, 9, 1, 5, 9, 5, 2, 8, 9, 5, 3, 5, 5, 9, 4, 8, 9, 7, 5, 2, 3, 6, 8, 9, 1, 9, 1, 4, 9, 3, 9, 1, 9, 8, 9, 5, 9, 8, 1, 1, 8, 3, 9, 5, 4, 6, 5, 1, 9, 4, 8, 9, 8, 9, 6, 2, 9, 3, 8, 2, 1, 3, 2, 4, 7, 9, 6, 7, 3, 9, 4},
-		{5, 5, 1, 7, 6, 4, 7, 1, 4, 4, 8, 9, 3, 8, 7, 8, 4, 3, 2, 8, 6, 8, 1, 6, 2, 1, 9, 1, 9, 2, 9, 7, 6, 8, 5, 1, 7, 6, 1, 4, 6, 6, 9, 4, 7, 7, 9, 6, 1, 6, 2, 1, 9, 9, 3, 4, 2, 7, 2, 9, 9, 1, 7, 9, 9, 6, 1, 2, 8, 3, 1, 7, 4, 6, 9, 3, 3, 4, 2, 7, 2, 8, 1, 3, 9, 9, 1, 7, 9, 1, 9, 7, 4, 8, 5, 6, 4, 3, 9, 1},
-		{9, 7, 7, 9, 4, 8, 1, 1, 6, 9, 9, 9, 3, 2, 1, 3, 6, 2, 8, 4, 9, 3, 3, 1, 1, 2, 4, 6, 9, 9, 6, 2, 8, 1, 1, 9, 1, 5, 8, 9, 1, 8, 6, 1, 2, 1, 8, 5, 1, 5, 9, 2, 4, 4, 7, 3, 4, 1, 8, 4, 9, 2, 7, 9, 1, 9, 9, 2, 6, 9, 4, 4, 1, 6, 6, 8, 8, 6, 3, 4, 9, 4, 1, 9, 9, 9, 9, 4, 9, 2, 9, 6, 9, 8, 1, 3, 1, 6, 4, 8},
-		{2, 9, 5, 9, 8, 7, 4, 8, 8, 5, 2, 3, 3, 9, 8, 9, 4, 9, 3, 3, 3, 1, 7, 1, 7, 1, 8, 1, 2, 9, 3, 2, 9, 5, 6, 9, 8, 7, 6, 8, 9, 1, 1, 8, 8, 1, 9, 6, 8, 2, 5, 8, 9, 1, 7, 7, 7, 7, 5, 1, 6, 2, 2, 2, 4, 7, 9, 2, 2, 1, 1, 5, 4, 7, 1, 8, 2, 7, 2, 3, 7, 8, 5, 8, 9, 4, 9, 2, 7, 2, 8, 9, 8, 6, 8, 9, 9, 8, 9, 8},
-		{6, 3, 3, 6, 1, 2, 8, 7, 2, 6, 6, 8, 3, 9, 3, 2, 1, 1, 5, 8, 8, 8, 8, 8, 9, 7, 8, 7, 2, 1, 2, 9, 9, 4, 7, 6, 2, 9, 1, 1, 4, 9, 9, 9, 1, 3, 8, 7, 2, 4, 1, 9, 8, 8, 3, 1, 9, 7, 5, 9, 1, 5, 8, 2, 5, 9, 9, 5, 6, 8, 1, 2, 8, 2, 3, 8, 3, 1, 2, 8, 7, 3, 2, 1, 3, 8, 6, 7, 4, 8, 3, 9, 3, 4, 9, 9, 3, 7, 3, 6},
-		{4, 1, 5, 9, 5, 1, 4, 9, 6, 2, 3, 9, 9, 6, 4, 9, 2, 6, 3, 9, 7, 6, 9, 8, 9, 1, 9, 9, 9, 8, 7, 9, 7, 9, 6, 9, 8, 8, 2, 1, 1, 7, 9, 1, 4, 7, 3, 7, 9, 5, 8, 1, 1, 1, 9, 2, 3, 5, 8, 9, 6, 8, 8, 6, 3, 2, 8, 9, 9, 6, 1, 9, 3, 9, 8, 4, 4, 1, 3, 1, 3, 1, 6, 8, 5, 5, 9, 2, 7, 7, 1, 2, 9, 1, 5, 7, 6, 2, 6, 6},
-		{1, 5, 9, 3, 4, 4, 2, 5, 9, 1, 9, 9, 6, 1, 4, 1, 9, 9, 2, 3, 3, 9, 7, 2, 9, 5, 5, 8, 7, 1, 8, 9, 9, 9, 1, 8, 3, 3, 1, 9, 9, 7, 1, 6, 9, 8, 6, 2, 2, 1, 9, 1, 7, 1, 2, 9, 8, 4, 5, 1, 1, 5, 5, 2, 6, 4, 3, 8, 5, 4, 9, 8, 6, 6, 1, 4, 6, 8, 7, 1, 8, 2, 1, 4, 8, 7, 2, 4, 8, 1, 4, 1, 7, 5, 8, 1, 7, 6, 5, 6},
-		{9, 7, 9, 2, 4, 9, 8, 2, 8, 7, 9, 4, 4, 3, 9, 8, 1, 9, 1, 8, 9, 3, 9, 7, 3, 1, 1, 4, 9, 7, 9, 3, 3, 9, 9, 6, 7, 8, 8, 6, 6, 7, 9, 5, 9, 9, 4, 7, 5, 9, 4, 9, 8, 7, 1, 1, 9, 9, 9, 5, 6, 3, 1, 9, 8, 3, 9, 4, 9, 3, 9, 5, 7, 9, 4, 2, 3, 7, 1, 8, 9, 9, 2, 9, 6, 4, 9, 1, 2, 3, 9, 6, 7, 8, 9, 7, 2, 9, 3, 4},
-		{6, 9, 5, 8, 8, 3, 2, 7, 6, 3, 7, 8, 4, 7, 3, 3, 8, 5, 1, 4, 1, 8, 8, 3, 8, 9, 3, 4, 3, 1, 5, 5, 9, 4, 7, 8, 9, 7, 9, 7, 3, 1, 5, 7, 1, 9, 7, 2, 7, 4, 3, 2, 6, 9, 8, 1, 7, 2, 1, 9, 1, 6, 6, 7, 9, 2, 9, 4, 9, 3, 9, 8, 1, 7, 4, 7, 8, 8, 9, 8, 7, 5, 9, 9, 5, 2, 7, 5, 6, 2, 7, 7, 9, 7, 9, 2, 3, 3, 8, 9},
-		{2, 9, 1, 2, 4, 8, 9, 7, 1, 4, 9, 3, 7, 7, 9, 5, 6, 6, 8, 1, 4, 9, 6, 5, 1, 8, 9, 3, 3, 8, 9, 7, 8, 8, 9, 9, 1, 7, 8, 5, 7, 4, 3, 2, 9, 2, 2, 9, 9, 5, 2, 6, 6, 5, 4, 2, 4, 5, 1, 1, 1, 7, 1, 5, 6, 9, 8, 8, 9, 8, 8, 3, 9, 6, 9, 2, 8, 1, 8, 9, 3, 6, 3, 1, 3, 5, 8, 1, 9, 1, 3, 7, 9, 2, 9, 1, 8, 9, 2, 6},
-		{7, 1, 9, 5, 7, 5, 6, 6, 7, 1, 1, 6, 1, 3, 6, 4, 8, 1, 2, 4, 9, 1, 5, 8, 6, 6, 4, 1, 9, 1, 8, 1, 9, 2, 9, 1, 7, 5, 9, 1, 1, 2, 8, 9, 9, 1, 7, 6, 9, 2, 1, 6, 5, 2, 9, 1, 1, 7, 6, 4, 1, 7, 2, 9, 1, 2, 8, 2, 2, 4, 6, 2, 5, 9, 3, 8, 1, 1, 8, 9, 9, 3, 3, 8, 1, 2, 2, 1, 2, 5, 8, 5, 6, 6, 2, 8, 1, 8, 7, 4},
-		{4, 9, 1, 9, 2, 5, 1, 9, 9, 3, 9, 2, 9, 9, 1, 9, 7, 9, 3, 6, 9, 8, 9, 9, 1, 7, 4, 8, 1, 9, 9, 8, 5, 7, 8, 2, 9, 6, 7, 7, 9, 9, 1, 1, 5, 9, 2, 9, 7, 3, 8, 9, 8, 5, 9, 5, 9, 6, 6, 7, 9, 2, 2, 1, 7, 2, 1, 1, 7, 2, 4, 7, 1, 9, 1, 1, 4, 7, 5, 4, 5, 8, 1, 3, 9, 9, 9, 2, 4, 8, 1, 1, 6, 2, 5, 1, 9, 1, 7, 5},
-		{2, 2, 5, 4, 9, 9, 5, 6, 2, 8, 7, 2, 7, 9, 8, 1, 4, 9, 6, 1, 7, 8, 7, 6, 9, 7, 9, 9, 1, 2, 2, 8, 5, 1, 1, 5, 1, 8, 2, 7, 1, 8, 7, 9, 9, 2, 6, 1, 3, 5, 9, 9, 8, 8, 8, 1, 4, 3, 1, 8, 9, 2, 9, 3, 4, 9, 5, 3, 8, 9, 8, 3, 6, 7, 9, 9, 9, 1, 8, 1, 7, 2, 6, 4, 9, 5, 2, 2, 5, 7, 1, 4, 9, 5, 3, 9, 3, 3, 6, 5},
-		{4, 9, 1, 9, 4, 6, 9, 5, 4, 9, 8, 4, 6, 9, 6, 4, 1, 3, 5, 3, 8, 7, 2, 9, 6, 9, 9, 2, 5, 9, 1, 4, 3, 1, 7, 4, 9, 9, 2, 7, 5, 2, 7, 5, 5, 4, 9, 9, 2, 6, 2, 9, 9, 1, 9, 1, 4, 1, 9, 3, 3, 9, 9, 5, 1, 2, 7, 1, 7, 9, 3, 4, 2, 8, 8, 7, 5, 1, 9, 4, 5, 4, 9, 7, 8, 5, 9, 9, 4, 1, 4, 7, 8, 9, 9, 1, 4, 7, 8, 3},
-		{8, 2, 4, 9, 2, 7, 7, 9, 1, 6, 9, 3, 9, 6, 1, 6, 5, 8, 9, 4, 9, 4, 6, 9, 2, 8, 9, 9, 2, 1, 5, 9, 8, 6, 2, 1, 9, 1, 1, 7, 9, 9, 9, 6, 1, 1, 1, 2, 6, 7, 8, 7, 7, 9, 7, 7, 6, 1, 6, 6, 3, 9, 4, 7, 8, 5, 5, 8, 8, 2, 5, 4, 8, 5, 5, 2, 9, 2, 1, 6, 9, 6, 5, 1, 1, 3, 3, 5, 3, 1, 9, 9, 7, 7, 9, 7, 7, 2, 7, 6},
-		{8, 9, 9, 7, 2, 4, 7, 8, 9, 1, 9, 4, 8, 1, 5, 8, 1, 9, 7, 9, 9, 7, 4, 2, 4, 5, 7, 7, 6, 6, 8, 1, 1, 9, 9, 6, 9, 5, 1, 2, 3, 9, 5, 9, 9, 9, 1, 9, 8, 7, 7, 7, 9, 8, 2, 7, 9, 3, 5, 9, 7, 7, 9, 2, 1, 6, 4, 2, 9, 9, 8, 6, 9, 1, 2, 9, 1, 8, 6, 2, 1, 9, 5, 7, 8, 8, 8, 6, 1, 1, 5, 8, 1, 6, 1, 2, 1, 2, 9, 5},
-		{6, 9, 4, 1, 9, 9, 7, 9, 7, 2, 8, 6, 8, 9, 5, 2, 1, 9, 2, 8, 3, 6, 5, 8, 8, 6, 3, 9, 1, 9, 7, 5, 7, 9, 7, 9, 1, 5, 2, 9, 9, 8, 3, 2, 5, 9, 4, 3, 4, 1, 7, 9, 8, 5, 8, 1, 1, 3, 2, 8, 9, 5, 5, 3, 9, 7, 5, 7, 9, 1, 1, 7, 9, 6, 6, 2, 3, 3, 5, 7, 1, 4, 9, 9, 3, 7, 2, 2, 6, 7, 1, 5, 9, 7, 9, 9, 8, 9, 2, 7},
-		{6, 6, 9, 4, 6, 6, 2, 7, 9, 1, 9, 8, 9, 8, 3, 9, 7, 9, 9, 9, 9, 9, 7, 2, 1, 8, 7, 4, 5, 9, 6, 5, 1, 2, 1, 9, 2, 4, 6, 7, 8, 2, 1, 3, 8, 3, 4, 9, 8, 1, 6, 7, 2, 1, 9, 6, 8, 9, 7, 3, 1, 8, 1, 9, 8, 1, 7, 6, 3, 8, 2, 1, 9, 9, 1, 8, 3, 9, 8, 5, 9, 9, 8, 1, 7, 9, 8, 4, 5, 9, 1, 6, 7, 8, 7, 7, 2, 4, 9, 8},
-		{8, 8, 5, 8, 2, 8, 8, 1, 8, 5, 2, 9, 1, 4, 5, 1, 3, 9, 6, 7, 9, 8, 9, 3, 7, 1, 5, 1, 1, 5, 4, 9, 9, 9, 4, 2, 8, 3, 3, 9, 8, 3, 4, 6, 1, 3, 5, 8, 6, 8, 9, 8, 5, 4, 6, 1, 9, 1, 9, 5, 5, 7, 3, 8, 8, 4, 9, 6, 1, 9, 3, 8, 1, 7, 1, 9, 4, 1, 9, 8, 3, 5, 1, 3, 2, 4, 9, 3, 8, 3, 9, 8, 7, 1, 3, 9, 9, 6, 1, 5},
-		{7, 5, 2, 9, 9, 1, 1, 6, 7, 5, 2, 1, 2, 8, 6, 8, 5, 9, 7, 9, 7, 7, 6, 3, 8, 1, 1, 9, 4, 2, 6, 8, 8, 2, 9, 4, 7, 1, 5, 9, 1, 9, 7, 2, 1, 6, 3, 2, 7, 9, 7, 2, 5, 9, 2, 8, 9, 1, 6, 1, 9, 7, 1, 8, 8, 5, 7, 9, 6, 8, 1, 4, 4, 8, 3, 3, 9, 9, 8, 8, 9, 8, 9, 2, 4, 8, 6, 4, 6, 5, 1, 2, 7, 2, 8, 4, 9, 9, 9, 5},
-		{9, 6, 9, 1, 4, 8, 4, 4, 1, 9, 1, 5, 8, 9, 6, 4, 9, 7, 1, 7, 7, 9, 9, 2, 7, 1, 7, 7, 9, 1, 1, 6, 8, 2, 1, 5, 9, 3, 8, 4, 2, 8, 1, 9, 1, 5, 9, 9, 4, 9, 9, 1, 5, 7, 6, 1, 8, 7, 5, 6, 1, 7, 9, 6, 8, 5, 3, 9, 1, 9, 1, 3, 4, 8, 9, 9, 9, 5, 4, 6, 9, 7, 8, 9, 7, 5, 6, 3, 1, 6, 8, 6, 9, 4, 8, 6, 7, 2, 2, 1},
-		{4, 8, 9, 4, 3, 1, 9, 6, 3, 5, 2, 1, 5, 1, 9, 2, 2, 7, 9, 1, 8, 8, 1, 1, 5, 6, 9, 8, 9, 1, 4, 9, 9, 1, 6, 9, 7, 4, 2, 4, 5, 1, 9, 8, 8, 4, 2, 9, 3, 9, 7, 6, 3, 8, 9, 9, 1, 1, 4, 4, 1, 2, 6, 2, 7, 8, 9, 9, 8, 3, 1, 1, 2, 1, 9, 1, 9, 7, 1, 6, 1, 3, 1, 1, 2, 1, 1, 3, 1, 6, 6, 1, 9, 9, 3, 7, 5, 4, 3, 1},
-		{1, 5, 1, 1, 6, 3, 9, 2, 7, 9, 8, 9, 3, 2, 7, 7, 9, 7, 8, 7, 1, 2, 5, 9, 7, 6, 1, 9, 2, 2, 7, 6, 2, 9, 4, 7, 1, 9, 3, 2, 1, 2, 3, 8, 8, 9, 3, 5, 9, 8, 9, 5, 1, 9, 1, 2, 3, 7, 2, 7, 7, 1, 8, 4, 9, 9, 9, 8, 8, 1, 7, 1, 1, 1, 9, 9, 7, 7, 5, 1, 9, 1, 3, 6, 9, 1, 8, 5, 9, 8, 2, 8, 5, 3, 9, 9, 5, 9, 4, 2},
-		{9, 9, 1, 5, 4, 2, 2, 4, 8, 9, 6, 4, 2, 1, 5, 1, 3, 6, 5, 8, 1, 5, 6, 7, 9, 7, 9, 9, 2, 4, 3, 5, 2, 8, 9, 4, 5, 8, 6, 2, 6, 2, 8, 4, 1, 9, 5, 9, 7, 4, 6, 9, 9, 9, 6, 6, 7, 9, 3, 7, 1, 1, 7, 4, 6, 9, 9, 1, 1, 6, 1, 5, 6, 7, 1, 9, 1, 9, 2, 4, 4, 7, 8, 8, 9, 8, 8, 7, 9, 9, 4, 1, 7, 5, 3, 9, 5, 1, 5, 8},
-		{1, 2, 6, 9, 5, 1, 9, 7, 9, 7, 3, 9, 3, 8, 2, 2, 1, 9, 9, 6, 9, 6, 9, 4, 6, 2, 9, 1, 9, 9, 9, 8, 7, 1, 1, 8, 5, 1, 1, 8, 9, 9, 4, 8, 8, 3, 5, 1, 9, 6, 9, 8, 9, 9, 6, 8, 7, 9, 1, 7, 8, 8, 3, 8, 1, 7, 6, 9, 6, 9, 8, 1, 9, 9, 6, 1, 1, 2, 1, 9, 1, 2, 8, 9, 5, 1, 8, 4, 9, 9, 9, 2, 3, 5, 9, 3, 9, 9, 8, 2},
-		{8, 9, 9, 8, 1, 6, 9, 1, 8, 9, 8, 9, 5, 5, 8, 5, 9, 6, 9, 9, 8, 9, 2, 1, 3, 6, 7, 8, 8, 4, 4, 8, 8, 6, 6, 9, 9, 2, 9, 5, 7, 9, 7, 1, 9, 9, 7, 1, 1, 9, 9, 4, 4, 9, 9, 8, 1, 7, 9, 9, 2, 1, 6, 1, 9, 8, 9, 5, 9, 3, 9, 6, 8, 8, 7, 5, 2, 9, 8, 6, 6, 3, 5, 2, 2, 8, 1, 8, 3, 5, 8, 5, 8, 5, 8, 9, 7, 1, 6, 2},
-		{7, 9, 2, 7, 6, 8, 2, 2, 1, 5, 9, 3, 1, 1, 8, 2, 9, 8, 6, 8, 3, 7, 3, 2, 4, 9, 5, 5, 1, 1, 7, 9, 2, 7, 4, 8, 1, 8, 6, 6, 9, 6, 2, 8, 4, 8, 7, 9, 4, 9, 1, 1, 1, 2, 1, 3, 9, 9, 8, 1, 7, 4, 9, 3, 1, 8, 7, 8, 1, 7, 1, 9, 9, 9, 2, 4, 1, 6, 3, 7, 8, 6, 7, 3, 2, 9, 6, 8, 3, 9, 6, 7, 1, 7, 8, 8, 9, 9, 1, 2},
-		{8, 3, 1, 1, 8, 6, 3, 8, 4, 9, 9, 8, 4, 4, 1, 8, 1, 3, 9, 3, 9, 1, 4, 9, 2, 9, 9, 4, 5, 9, 9, 7, 2, 3, 1, 9, 7, 9, 9, 7, 9, 3, 6, 8, 1, 8, 6, 8, 1, 2, 6, 6, 4, 6, 7, 6, 1, 2, 9, 9, 3, 5, 9, 9, 4, 3, 7, 8, 4, 2, 9, 3, 1, 8, 9, 7, 6, 9, 2, 6, 9, 1, 1, 2, 3, 1, 9, 8, 1, 5, 7, 1, 9, 6, 3, 1, 3, 8, 2, 8},
-		{3, 9, 1, 2, 8, 1, 1, 9, 6, 2, 5, 3, 9, 9, 3, 3, 3, 7, 2, 7, 5, 7, 8, 6, 2, 8, 8, 8, 9, 7, 9, 8, 2, 9, 4, 3, 6, 7, 9, 1, 9, 1, 4, 8, 1, 8, 5, 5, 8, 9, 3, 6, 2, 9, 8, 3, 9, 2, 6, 3, 1, 5, 1, 4, 1, 8, 4, 2, 7, 9, 5, 9, 8, 8, 6, 8, 5, 9, 8, 4, 2, 8, 2, 1, 9, 7, 1, 1, 4, 7, 2, 6, 6, 6, 9, 9, 1, 6, 3, 9},
-		{6, 9, 1, 6, 6, 8, 4, 6, 4, 2, 1, 7, 4, 8, 4, 9, 9, 4, 9, 2, 2, 9, 6, 7, 8, 7, 1, 4, 7, 9, 8, 1, 9, 9, 1, 8, 2, 2, 8, 8, 4, 1, 9, 2, 3, 4, 9, 3, 6, 8, 9, 6, 9, 9, 5, 1, 1, 9, 6, 5, 6, 4, 1, 1, 6, 3, 1, 8, 5, 1, 5, 2, 8, 7, 8, 9, 9, 8, 1, 9, 7, 3, 9, 8, 3, 5, 2, 4, 1, 3, 9, 5, 8, 8, 3, 2, 2, 9, 3, 8},
-		{3, 8, 9, 9, 5, 9, 1, 8, 9, 9, 1, 2, 1, 5, 2, 8, 8, 9, 9, 1, 8, 9, 9, 7, 1, 6, 9, 2, 3, 8, 7, 9, 7, 9, 6, 1, 2, 8, 8, 9, 5, 3, 7, 6, 8, 1, 7, 3, 7, 8, 3, 7, 5, 1, 6, 4, 8, 1, 3, 5, 1, 1, 5, 3, 8, 6, 4, 9, 6, 3, 8, 4, 9, 1, 5, 1, 7, 7, 9, 8, 1, 6, 5, 4, 9, 6, 5, 1, 2, 3, 6, 1, 1, 1, 7, 2, 3, 5, 9, 4},
-		{3, 7, 3, 3, 7, 4, 8, 9, 2, 5, 5, 8, 6, 2, 9, 7, 8, 8, 2, 8, 1, 7, 1, 7, 4, 1, 7, 9, 1, 6, 8, 8, 2, 9, 2, 1, 9, 8, 5, 7, 2, 2, 2, 8, 2, 9, 8, 7, 9, 7, 3, 7, 3, 4, 1, 1, 4, 1, 8, 3, 9, 2, 8, 3, 4, 1, 1, 1, 8, 9, 7, 2, 5, 1, 6, 9, 2, 2, 6, 1, 5, 8, 9, 4, 1, 3, 4, 1, 8, 4, 6, 7, 2, 9, 7, 6, 8, 6, 6, 9},
-		{9, 7, 8, 9, 4, 9, 9, 6, 2, 7, 8, 9, 6, 9, 9, 3, 5, 3, 9, 2, 7, 1, 9, 5, 8, 5, 7, 7, 7, 9, 9, 4, 1, 9, 3, 3, 8, 9, 5, 2, 8, 7, 2, 8, 1, 5, 1, 4, 8, 5, 7, 6, 1, 2, 7, 5, 6, 1, 6, 3, 9, 8, 2, 9, 5, 2, 8, 5, 9, 6, 5, 1, 9, 3, 9, 9, 3, 4, 9, 6, 5, 1, 5, 3, 8, 2, 7, 6, 9, 8, 9, 9, 9, 4, 5, 2, 8, 4, 8, 7},
-		{7, 3, 8, 8, 9, 8, 1, 1, 7, 8, 9, 8, 5, 9, 9, 7, 3, 8, 7, 2, 8, 2, 4, 9, 9, 4, 5, 1, 8, 1, 9, 9, 9, 1, 1, 6, 5, 8, 7, 9, 6, 8, 2, 4, 6, 9, 8, 8, 2, 1, 9, 9, 5, 5, 1, 1, 6, 5, 1, 1, 9, 2, 9, 8, 5, 6, 6, 5, 2, 7, 9, 8, 9, 1, 1, 2, 5, 7, 9, 2, 6, 2, 9, 6, 6, 8, 6, 1, 9, 8, 9, 6, 4, 6, 1, 1, 5, 3, 9, 9},
-		{5, 9, 9, 1, 9, 4, 2, 7, 6, 1, 4, 9, 2, 9, 1, 9, 7, 3, 2, 3, 2, 5, 1, 4, 9, 9, 1, 2, 3, 8, 7, 6, 9, 4, 1, 1, 8, 1, 8, 8, 7, 1, 3, 9, 5, 6, 6, 6, 8, 2, 4, 8, 8, 1, 2, 8, 7, 4, 1, 9, 8, 2, 7, 4, 8, 8, 8, 5, 8, 1, 1, 9, 1, 7, 9, 8, 9, 8, 2, 6, 9, 6, 1, 2, 9, 2, 9, 9, 7, 2, 8, 7, 8, 8, 1, 5, 2, 3, 9, 2},
-		{4, 8, 9, 3, 9, 2, 9, 4, 6, 2, 9, 1, 6, 6, 6, 9, 9, 1, 7, 2, 8, 4, 9, 6, 1, 2, 7, 8, 9, 3, 9, 7, 5, 1, 9, 8, 9, 9, 2, 5, 1, 3, 2, 1, 9, 9, 4, 9, 6, 1, 9, 9, 1, 8, 9, 1, 3, 7, 9, 9, 8, 5, 4, 9, 7, 5, 1, 2, 6, 6, 2, 7, 9, 9, 3, 1, 2, 6, 1, 7, 1, 4, 8, 9, 9, 9, 8, 8, 4, 8, 6, 7, 1, 9, 8, 6, 9, 1, 8, 5},
-		{4, 9, 9, 7, 9, 9, 3, 5, 8, 1, 8, 9, 9, 5, 7, 3, 5, 5, 9, 3, 6, 8, 4, 3, 1, 9, 8, 7, 7, 8, 7, 9, 4, 9, 2, 4, 9, 4, 8, 7, 6, 1, 6, 9, 9, 6, 2, 3, 6, 4, 6, 5, 1, 6, 6, 4, 3, 8, 2, 9, 5, 4, 7, 7, 5, 1, 1, 8, 5, 2, 9, 5, 2, 9, 9, 3, 9, 1, 1, 9, 1, 6, 9, 9, 9, 5, 1, 3, 8, 1, 9, 3, 1, 5, 5, 6, 3, 7, 3, 9},
-		{5, 6, 9, 4, 9, 6, 2, 6, 3, 3, 9, 9, 9, 7, 2, 3, 2, 3, 2, 5, 1, 9, 1, 9, 6, 7, 7, 8, 8, 8, 6, 8, 4, 1, 8, 6, 3, 6, 5, 9, 7, 7, 5, 5, 4, 3, 3, 1, 5, 3, 1, 4, 1, 8, 7, 9, 9, 9, 8, 1, 9, 9, 7, 5, 6, 7, 1, 4, 8, 9, 3, 8, 4, 3, 1, 2, 1, 1, 1, 1, 2, 5, 1, 9, 4, 1, 9, 1, 1, 9, 1, 6, 8, 9, 3, 1, 8, 8, 4, 2},
-		{8, 1, 1, 7, 9, 1, 7, 1, 7, 1, 3, 9, 7, 2, 9, 1, 3, 3, 1, 1, 8, 9, 2, 5, 9, 2, 5, 4, 5, 8, 5, 7, 1, 6, 5, 9, 1, 7, 1, 1, 9, 6, 6, 7, 4, 7, 2, 9, 9, 1, 6, 9, 2, 6, 9, 5, 9, 1, 5, 5, 9, 6, 9, 6, 1, 4, 7, 1, 9, 2, 2, 9, 9, 7, 2, 2, 9, 2, 4, 2, 1, 2, 1, 7, 9, 1, 9, 9, 4, 7, 9, 9, 8, 5, 9, 2, 7, 7, 7, 8},
-		{5, 8, 7, 7, 1, 9, 5, 8, 6, 9, 1, 9, 9, 3, 6, 9, 7, 3, 8, 3, 9, 9, 9, 9, 2, 9, 9, 6, 9, 9, 2, 3, 5, 1, 9, 1, 6, 9, 5, 4, 1, 9, 8, 3, 2, 1, 9, 1, 6, 3, 2, 4, 9, 9, 9, 5, 3, 5, 7, 9, 9, 1, 7, 2, 4, 9, 1, 1, 4, 7, 9, 7, 7, 8, 6, 3, 2, 7, 6, 5, 7, 1, 6, 4, 3, 9, 7, 4, 1, 9, 7, 9, 3, 3, 9, 9, 1, 2, 2, 5},
-		{9, 9, 8, 9, 9, 3, 7, 1, 3, 2, 1, 1, 6, 3, 9, 6, 9, 9, 1, 2, 8, 2, 6, 6, 5, 5, 5, 3, 7, 8, 6, 3, 8, 6, 7, 8, 7, 2, 5, 1, 9, 2, 7, 6, 2, 8, 6, 4, 5, 1, 8, 9, 1, 9, 6, 2, 7, 1, 4, 8, 4, 4, 7, 2, 7, 6, 5, 7, 6, 7, 7, 9, 8, 2, 2, 3, 8, 8, 2, 6, 4, 9, 6, 9, 3, 7, 3, 9, 8, 9, 1, 2, 7, 1, 9, 4, 1, 7, 1, 8},
-		{9, 8, 8, 1, 8, 1, 7, 9, 2, 3, 9, 7, 6, 7, 3, 9, 2, 8, 9, 5, 8, 7, 4, 4, 1, 9, 7, 7, 9, 3, 2, 1, 1, 8, 7, 6, 9, 9, 7, 8, 9, 1, 6, 7, 1, 6, 2, 5, 7, 2, 8, 6, 9, 7, 6, 1, 1, 9, 1, 2, 9, 9, 8, 2, 9, 3, 5, 7, 9, 6, 9, 3, 9, 2, 9, 9, 7, 4, 5, 9, 9, 8, 7, 9, 7, 6, 1, 1, 5, 2, 5, 3, 9, 9, 3, 3, 8, 9, 4, 6},
-		{7, 9, 7, 1, 7, 2, 1, 1, 1, 3, 7, 7, 9, 6, 3, 9, 5, 1, 9, 6, 9, 3, 8, 9, 6, 6, 3, 6, 5, 3, 2, 1, 9, 8, 9, 5, 8, 1, 5, 4, 5, 3, 3, 4, 9, 3, 9, 5, 5, 2, 8, 4, 6, 2, 5, 8, 1, 6, 9, 1, 8, 6, 7, 8, 5, 9, 1, 1, 5, 8, 9, 3, 8, 2, 9, 9, 2, 9, 9, 5, 1, 4, 9, 5, 6, 5, 4, 5, 2, 8, 9, 1, 8, 9, 2, 2, 5, 2, 4, 6},
-		{1, 9, 3, 4, 2, 5, 5, 8, 4, 1, 1, 4, 3, 9, 2, 4, 2, 1, 9, 9, 6, 3, 3, 1, 8, 2, 1, 2, 5, 3, 2, 3, 4, 9, 1, 8, 5, 1, 6, 9, 8, 6, 4, 6, 2, 9, 3, 4, 6, 8, 1, 2, 6, 1, 1, 4, 9, 8, 2, 8, 1, 9, 3, 5, 8, 8, 3, 9, 7, 5, 2, 9, 6, 7, 2, 9, 8, 9, 8, 7, 8, 9, 5, 9, 9, 2, 9, 5, 2, 7, 5, 4, 7, 1, 2, 3, 9, 9, 3, 5},
-		{9, 3, 8, 9, 2, 5, 9, 1, 9, 5, 1, 2, 2, 4, 7, 9, 4, 5, 9, 6, 7, 3, 5, 8, 7, 5, 3, 5, 2, 8, 7, 7, 1, 4, 3, 1, 7, 9, 8, 4, 2, 9, 1, 5, 2, 9, 9, 7, 9, 1, 8, 4, 9, 9, 3, 8, 9, 6, 1, 7, 1, 5, 9, 1, 5, 6, 7, 8, 9, 9, 9, 3, 9, 3, 2, 4, 8, 1, 5, 1, 1, 9, 1, 7, 2, 7, 9, 1, 9, 3, 6, 5, 6, 9, 4, 1, 9, 1, 7, 1},
-		{1, 2, 1, 9, 5, 4, 5, 1, 5, 4, 8, 9, 9, 9, 2, 2, 2, 3, 8, 1, 4, 8, 1, 3, 9, 9, 9, 9, 1, 2, 5, 1, 9, 2, 2, 8, 4, 1, 1, 9, 4, 5, 4, 4, 8, 9, 1, 1, 2, 1, 6, 2, 2, 4, 7, 9, 7, 7, 4, 1, 7, 1, 2, 6, 9, 3, 6, 5, 9, 1, 6, 8, 3, 7, 7, 9, 2, 8, 9, 1, 1, 8, 9, 2, 2, 9, 5, 8, 9, 4, 1, 9, 5, 2, 1, 6, 9, 5, 2, 9},
-		{8, 9, 3, 1, 3, 2, 6, 6, 4, 5, 1, 5, 5, 7, 1, 2, 1, 9, 3, 9, 4, 4, 9, 7, 7, 9, 9, 2, 1, 2, 8, 1, 9, 7, 7, 8, 2, 5, 9, 3, 8, 9, 6, 2, 3, 2, 9, 6, 9, 3, 6, 1, 8, 8, 8, 9, 8, 3, 7, 8, 3, 1, 5, 8, 4, 8, 2, 9, 6, 9, 6, 6, 2, 6, 1, 3, 4, 7, 1, 4, 9, 8, 3, 6, 3, 9, 9, 7, 1, 5, 8, 1, 2, 8, 9, 8, 3, 9, 1, 7},
-		{9, 3, 9, 1, 8, 1, 4, 3, 3, 4, 9, 7, 8, 9, 4, 3, 8, 9, 3, 8, 9, 9, 8, 9, 8, 7, 1, 9, 2, 3, 8, 9, 2, 7, 3, 4, 5, 8, 1, 9, 1, 5, 8, 8, 5, 9, 7, 4, 2, 1, 3, 7, 3, 8, 6, 1, 9, 9, 8, 3, 1, 6, 1, 1, 6, 7, 1, 6, 2, 7, 8, 6, 8, 6, 2, 9, 7, 4, 3, 9, 7, 4, 5, 2, 9, 8, 9, 5, 8, 6, 7, 6, 8, 9, 7, 2, 2, 8, 9, 9},
-		{8, 8, 9, 9, 6, 6, 1, 8, 1, 3, 5, 8, 8, 9, 9, 8, 9, 5, 7, 9, 2, 6, 8, 2, 3, 1, 4, 5, 9, 8, 1, 2, 9, 3, 9, 7, 5, 6, 8, 4, 9, 7, 7, 8, 9, 8, 7, 4, 9, 6, 1, 9, 1, 4, 8, 8, 7, 5, 1, 4, 9, 8, 9, 5, 5, 6, 9, 9, 9, 1, 7, 9, 6, 2, 7, 6, 6, 8, 3, 2, 2, 3, 9, 7, 4, 5, 1, 2, 5, 2, 2, 9, 9, 6, 7, 8, 4, 2, 8, 1},
-		{8, 1, 8, 9, 9, 9, 7, 3, 4, 1, 8, 6, 1, 6, 3, 2, 2, 9, 6, 1, 1, 7, 8, 7, 3, 9, 5, 2, 9, 7, 6, 1, 5, 5, 9, 4, 8, 3, 2, 6, 1, 8, 1, 5, 1, 7, 8, 7, 6, 9, 2, 5, 2, 3, 4, 7, 9, 7, 5, 1, 3, 2, 8, 4, 9, 8, 9, 7, 7, 9, 6, 1, 4, 1, 1, 1, 1, 7, 1, 8, 2, 4, 9, 8, 3, 1, 4, 4, 7, 1, 9, 9, 8, 2, 8, 3, 8, 6, 9, 2},
-		{6, 8, 2, 9, 9, 7, 4, 7, 4, 8, 8, 8, 8, 2, 8, 1, 1, 5, 2, 9, 9, 5, 1, 3, 9, 1, 6, 9, 9, 4, 3, 9, 5, 1, 9, 7, 8, 6, 9, 7, 7, 1, 4, 9, 8, 9, 8, 5, 9, 2, 3, 6, 9, 7, 9, 6, 8, 8, 4, 8, 9, 3, 6, 7, 7, 6, 7, 1, 9, 3, 9, 6, 8, 4, 9, 9, 4, 6, 9, 2, 7, 1, 3, 4, 7, 8, 8, 3, 9, 2, 3, 9, 7, 8, 4, 9, 6, 4, 8, 6},
-		{3, 5, 8, 2, 8, 2, 5, 9, 2, 9, 8, 7, 1, 7, 1, 2, 7, 7, 3, 8, 2, 9, 4, 4, 2, 9, 4, 4, 9, 8, 8, 7, 1, 7, 1, 8, 3, 3, 9, 9, 6, 4, 4, 8, 2, 7, 5, 9, 7, 9, 4, 4, 2, 6, 9, 9, 4, 9, 2, 9, 1, 8, 9, 6, 7, 8, 8, 9, 3, 6, 2, 1, 8, 3, 9, 9, 3, 8, 4, 6, 4, 2, 4, 7, 9, 2, 4, 6, 3, 2, 6, 4, 8, 7, 2, 1, 2, 2, 1, 6},
-		{1, 2, 8, 1, 8, 4, 9, 1, 8, 9, 8, 6, 7, 9, 2, 8, 1, 2, 5, 1, 2, 8, 2, 4, 7, 9, 9, 7, 9, 9, 1, 2, 7, 4, 9, 8, 8, 5, 9, 2, 2, 5, 4, 1, 9, 7, 1, 1, 4, 9, 7, 6, 9, 6, 9, 9, 2, 3, 7, 9, 2, 9, 2, 6, 3, 1, 9, 2, 7, 1, 9, 8, 8, 2, 9, 7, 8, 3, 4, 9, 2, 7, 3, 6, 9, 5, 3, 9, 6, 3, 7, 9, 9, 6, 1, 4, 2, 8, 6, 2},
-		{6, 9, 7, 5, 3, 8, 5, 3, 8, 6, 5, 2, 9, 1, 4, 7, 2, 3, 1, 1, 1, 6, 5, 3, 8, 8, 2, 7, 9, 1, 6, 6, 8, 8, 4, 2, 8, 5, 8, 3, 6, 8, 5, 1, 1, 9, 9, 9, 9, 9, 2, 2, 1, 5, 5, 6, 8, 7, 8, 6, 8, 8, 7, 2, 1, 9, 1, 9, 3, 4, 9, 6, 9, 1, 8, 9, 9, 1, 2, 6, 1, 6, 9, 3, 9, 9, 7, 2, 4, 1, 6, 6, 9, 9, 9, 3, 7, 6, 1, 9},
-		{9, 1, 4, 2, 5, 8, 1, 3, 9, 4, 8, 8, 7, 7, 5, 9, 4, 8, 9, 9, 9, 2, 3, 9, 1, 4, 9, 5, 1, 5, 4, 8, 8, 3, 3, 6, 4, 1, 6, 5, 3, 9, 9, 1, 6, 3, 5, 2, 6, 1, 9, 3, 4, 6, 8, 3, 4, 8, 6, 9, 8, 6, 8, 2, 8, 1, 6, 2, 8, 5, 1, 7, 2, 5, 1, 6, 7, 9, 2, 9, 3, 3, 8, 8, 1, 3, 6, 9, 3, 6, 5, 1, 8, 3, 6, 9, 9, 7, 3, 7},
-		{3, 4, 1, 1, 7, 7, 9, 7, 9, 9, 6, 3, 9, 9, 1, 8, 5, 9, 7, 9, 9, 7, 7, 3, 5, 2, 7, 8, 2, 7, 9, 5, 2, 2, 1, 9, 1, 1, 9, 9, 8, 6, 6, 4, 9, 7, 5, 2, 8, 5, 9, 2, 7, 3, 6, 2, 6, 7, 8, 1, 3, 2, 8, 1, 5, 6, 9, 9, 9, 9, 1, 9, 9, 1, 1, 9, 4, 1, 2, 8, 2, 3, 7, 7, 2, 2, 2, 7, 6, 7, 7, 9, 9, 8, 9, 4, 6, 3, 8, 4},
-		{2, 1, 1, 6, 4, 1, 1, 9, 6, 7, 5, 1, 5, 5, 1, 4, 9, 4, 2, 1, 1, 9, 3, 6, 4, 4, 2, 8, 9, 5, 9, 4, 1, 9, 1, 1, 8, 1, 1, 7, 9, 5, 6, 3, 7, 8, 1, 5, 7, 6, 4, 9, 9, 2, 2, 1, 1, 9, 8, 3, 5, 1, 3, 9, 4, 3, 8, 5, 8, 7, 4, 3, 6, 7, 9, 3, 3, 4, 7, 6, 1, 1, 9, 8, 8, 9, 8, 8, 3, 9, 5, 8, 4, 4, 9, 9, 9, 1, 4, 6},
-		{4, 9, 6, 9, 5, 9, 4, 2, 7, 4, 6, 1, 9, 9, 6, 6, 9, 6, 8, 6, 1, 8, 9, 9, 6, 9, 9, 5, 3, 5, 5, 1, 7, 3, 4, 2, 3, 2, 8, 1, 2, 1, 7, 1, 9, 5, 2, 9, 9, 9, 8, 5, 4, 1, 1, 2, 5, 4, 8, 4, 9, 6, 9, 7, 4, 7, 4, 4, 9, 1, 1, 1, 7, 9, 4, 2, 2, 6, 6, 9, 8, 5, 6, 9, 9, 1, 2, 2, 5, 9, 4, 8, 8, 3, 3, 8, 5, 6, 1, 5},
-		{4, 9, 1, 7, 2, 9, 9, 2, 2, 1, 6, 2, 9, 5, 9, 9, 7, 9, 9, 6, 9, 9, 8, 4, 9, 4, 5, 2, 9, 3, 8, 6, 3, 7, 3, 6, 8, 9, 9, 8, 1, 1, 6, 9, 5, 9, 3, 8, 9, 9, 5, 8, 1, 7, 7, 1, 9, 8, 1, 5, 7, 2, 3, 6, 9, 8, 8, 1, 8, 4, 9, 8, 9, 3, 8, 9, 7, 1, 9, 2, 6, 8, 8, 8, 7, 1, 8, 2, 1, 9, 7, 8, 6, 9, 9, 9, 9, 7, 9, 4},
-		{9, 9, 3, 3, 1, 9, 9, 7, 3, 4, 9, 2, 5, 9, 2, 8, 8, 1, 4, 8, 1, 4, 6, 9, 9, 9, 7, 4, 3, 1, 9, 5, 3, 2, 1, 5, 8, 1, 5, 1, 9, 2, 5, 5, 1, 4, 9, 1, 2, 9, 4, 1, 8, 8, 6, 2, 2, 6, 1, 9, 3, 2, 9, 1, 8, 7, 7, 9, 5, 1, 3, 4, 2, 2, 5, 4, 6, 4, 8, 6, 9, 4, 2, 9, 3, 5, 7, 2, 1, 3, 3, 9, 4, 4, 4, 9, 5, 1, 1, 3},
-		{9, 9, 8, 7, 8, 8, 5, 1, 9, 6, 6, 6, 9, 5, 9, 4, 2, 4, 4, 9, 3, 7, 4, 6, 9, 5, 8, 8, 9, 9, 9, 2, 9, 4, 9, 6, 7, 5, 3, 8, 3, 4, 7, 6, 7, 9, 7, 9, 2, 4, 1, 3, 7, 3, 4, 9, 9, 9, 1, 1, 9, 3, 9, 7, 1, 8, 9, 9, 9, 9, 7, 5, 8, 8, 5, 1, 9, 9, 8, 5, 3, 9, 3, 2, 1, 1, 8, 5, 3, 9, 2, 7, 9, 1, 8, 2, 3, 3, 1, 9},
-		{6, 9, 8, 9, 9, 8, 5, 1, 2, 9, 8, 3, 3, 9, 1, 1, 7, 8, 2, 3, 5, 1, 8, 6, 3, 8, 6, 9, 5, 8, 3, 4, 9, 2, 9, 3, 2, 2, 9, 1, 8, 9, 7, 3, 9, 5, 8, 2, 5, 9, 8, 2, 9, 1, 6, 2, 2, 7, 1, 7, 8, 8, 9, 2, 9, 2, 8, 4, 8, 1, 1, 4, 9, 1, 8, 5, 2, 9, 4, 5, 3, 1, 9, 9, 9, 1, 6, 9, 8, 9, 8, 7, 7, 1, 8, 9, 9, 9, 9, 3},
-		{8, 1, 8, 1, 7, 9, 9, 9, 4, 4, 1, 4, 2, 1, 9, 6, 7, 8, 6, 7, 9, 1, 9, 8, 7, 4, 7, 4, 1, 3, 7, 1, 1, 9, 4, 7, 1, 9, 7, 1, 7, 1, 1, 2, 4, 9, 4, 5, 6, 3, 1, 5, 9, 9, 7, 9, 9, 4, 8, 5, 5, 9, 1, 4, 1, 6, 9, 1, 9, 6, 3, 3, 7, 5, 7, 4, 8, 2, 1, 6, 1, 8, 6, 4, 2, 8, 9, 9, 9, 8, 9, 4, 9, 9, 9, 9, 9, 9, 6, 7},
-		{9, 9, 7, 7, 6, 7, 4, 3, 9, 8, 5, 8, 6, 1, 8, 1, 5, 1, 1, 4, 2, 9, 8, 3, 9, 9, 1, 8, 6, 9, 7, 8, 8, 5, 8, 3, 6, 8, 9, 4, 9, 7, 9, 8, 7, 7, 1, 1, 5, 9, 6, 9, 2, 2, 8, 2, 7, 6, 7, 9, 6, 2, 3, 8, 4, 9, 2, 2, 8, 9, 8, 1, 1, 6, 9, 6, 1, 7, 6, 6, 2, 9, 5, 4, 6, 7, 1, 9, 7, 8, 7, 9, 7, 9, 9, 3, 2, 1, 5, 5},
-		{9, 6, 4, 9, 3, 2, 2, 5, 7, 8, 2, 5, 4, 3, 2, 1, 8, 1, 3, 6, 9, 2, 4, 8, 8, 1, 8, 9, 2, 8, 7, 6, 1, 7, 6, 9, 6, 8, 9, 7, 8, 9, 2, 6, 1, 3, 3, 2, 7, 1, 4, 8, 7, 9, 6, 6, 6, 2, 6, 1, 8, 4, 1, 7, 4, 3, 2, 7, 9, 6, 6, 4, 9, 7, 5, 9, 3, 4, 5, 8, 4, 4, 4, 9, 7, 5, 2, 3, 3, 6, 7, 4, 5, 1, 4, 8, 1, 1, 5, 2},
-		{5, 1, 2, 9, 5, 9, 9, 1, 4, 1, 8, 6, 6, 5, 1, 9, 1, 8, 9, 8, 9, 6, 7, 1, 9, 2, 9, 9, 8, 9, 8, 1, 9, 7, 8, 5, 1, 3, 9, 3, 8, 9, 4, 8, 6, 9, 2, 1, 1, 1, 8, 9, 2, 8, 9, 9, 2, 1, 2, 8, 9, 6, 7, 3, 5, 3, 3, 5, 8, 9, 2, 1, 3, 7, 8, 9, 9, 9, 8, 7, 4, 8, 4, 6, 9, 2, 3, 3, 7, 6, 9, 4, 7, 9, 4, 1, 9, 8, 3, 8},
-		{1, 9, 1, 4, 1, 6, 7, 7, 7, 3, 8, 1, 5, 9, 3, 9, 6, 8, 9, 4, 8, 4, 4, 8, 9, 5, 9, 3, 5, 9, 9, 1, 9, 1, 1, 9, 9, 4, 5, 3, 9, 2, 1, 6, 2, 3, 4, 2, 8, 2, 2, 6, 8, 9, 9, 3, 2, 7, 7, 8, 9, 1, 3, 9, 8, 2, 8, 5, 3, 1, 3, 9, 1, 8, 7, 7, 7, 3, 9, 7, 9, 1, 9, 5, 8, 1, 9, 2, 1, 5, 8, 4, 4, 1, 9, 8, 4, 1, 9, 3},
-		{8, 3, 3, 8, 4, 7, 4, 5, 1, 9, 1, 6, 7, 5, 9, 9, 4, 5, 5, 2, 7, 2, 1, 9, 1, 2, 6, 3, 3, 9, 3, 5, 1, 9, 9, 9, 9, 9, 9, 9, 1, 1, 7, 4, 7, 1, 9, 7, 1, 1, 4, 2, 4, 6, 9, 7, 9, 9, 4, 4, 4, 8, 8, 5, 1, 6, 1, 8, 6, 1, 1, 9, 6, 1, 7, 8, 9, 9, 4, 6, 9, 1, 6, 9, 7, 6, 8, 9, 7, 9, 4, 9, 3, 2, 6, 4, 9, 3, 8, 7},
-		{1, 5, 5, 9, 1, 4, 8, 8, 8, 9, 2, 3, 8, 8, 7, 9, 8, 1, 4, 9, 6, 9, 1, 1, 3, 1, 1, 4, 3, 6, 9, 9, 9, 1, 1, 7, 8, 8, 5, 8, 1, 1, 4, 1, 8, 2, 2, 8, 9, 9, 9, 1, 4, 3, 7, 2, 5, 7, 8, 1, 6, 1, 4, 9, 5, 2, 9, 8, 7, 1, 1, 3, 9, 7, 2, 7, 8, 9, 8, 9, 9, 9, 9, 7, 7, 7, 8, 7, 4, 7, 9, 8, 9, 2, 6, 1, 1, 9, 4, 1},
-		{2, 1, 9, 5, 9, 5, 6, 6, 2, 2, 3, 8, 9, 9, 1, 1, 4, 6, 8, 2, 9, 5, 9, 1, 7, 2, 1, 9, 8, 8, 7, 2, 1, 4, 6, 6, 5, 8, 1, 2, 8, 8, 7, 8, 9, 2, 1, 9, 9, 8, 8, 3, 6, 8, 7, 9, 3, 3, 7, 8, 6, 4, 9, 7, 9, 9, 9, 2, 7, 7, 4, 7, 8, 2, 8, 7, 1, 2, 3, 4, 1, 8, 4, 9, 2, 2, 6, 9, 6, 9, 4, 9, 4, 3, 3, 2, 6, 7, 4, 9},
-		{9, 8, 1, 2, 7, 4, 9, 3, 1, 1, 8, 6, 5, 9, 9, 9, 3, 6, 2, 6, 9, 2, 5, 9, 3, 8, 7, 9, 1, 3, 6, 2, 3, 6, 2, 6, 3, 7, 1, 9, 2, 5, 9, 2, 6, 6, 5, 4, 9, 6, 3, 7, 9, 1, 3, 6, 4, 5, 6, 7, 5, 2, 9, 3, 9, 6, 8, 1, 7, 2, 4, 6, 9, 7, 7, 2, 7, 6, 9, 8, 4, 5, 9, 9, 6, 4, 9, 6, 6, 4, 9, 2, 1, 1, 9, 4, 4, 2, 8, 9},
-		{2, 2, 9, 2, 8, 8, 3, 7, 2, 3, 8, 8, 8, 9, 7, 6, 6, 1, 1, 9, 9, 3, 6, 8, 8, 7, 7, 4, 8, 9, 2, 7, 9, 3, 9, 6, 2, 3, 2, 9, 4, 3, 2, 9, 1, 9, 3, 4, 9, 3, 2, 8, 2, 7, 1, 8, 4, 8, 8, 8, 1, 3, 4, 2, 4, 2, 2, 1, 8, 9, 3, 1, 5, 8, 8, 9, 3, 8, 3, 1, 9, 3, 1, 3, 9, 1, 4, 6, 8, 3, 9, 4, 9, 8, 5, 6, 8, 1, 7, 6},
+// One tile of the cave's risk levels; the full cave is five tiles each way.
+type RiskMap [][]int
+
+// The bottom right corner of the first tile, and of the full cave.
+func (m RiskMap) Tile() Point { return Point{row: len(m) - 1, col: len(m[0]) - 1} }
+func (m RiskMap) Full() Point { return Point{row: 5*len(m) - 1, col: 5*len(m[0]) - 1} }
+
+type chiton struct {
+	shortestPath func(m RiskMap, target Point) (int, []Point)
+	// if set, where the parts keep the routes they find, to be written out
+	// or drawn afterwards without searching again
+	routes *[2][]Point
+}
+
+func (chiton) Parse(input io.Reader) (RiskMap, error) {
+	grid, err := readDigitGrid(input)
+	return RiskMap(grid), err
+}
+
+func (c chiton) Part1(m RiskMap) (Answer, error) {
+	return c.solvePart(m, 1, m.Tile()), nil
+}
+
+func (c chiton) Part2(m RiskMap) (Answer, error) {
+	return c.solvePart(m, 2, m.Full()), nil
+}
+
+func (c chiton) solvePart(m RiskMap, part int, target Point) int {
+	risk, route := c.shortestPath(m, target)
+	if c.routes != nil {
+		c.routes[part-1] = route
 	}
-)
+	return risk
+}
+
+// The routes both parts found.
+func (c chiton) foundRoutes(m RiskMap) ([]Point, []Point) {
+	if c.routes == nil || c.routes[0] == nil || c.routes[1] == nil {
+		c = chiton{shortestPath: c.shortestPath, routes: &[2][]Point{}}
+		c.solvePart(m, 1, m.Tile())
+		c.solvePart(m, 2, m.Full())
+	}
+	return c.routes[0], c.routes[1]
+}
+
+// With -route, writes out the routes the parts found.
+func (c chiton) AfterSolving(m RiskMap) error {
+	if *routePath == "" {
+		return nil
+	}
+	route1, route2 := c.foundRoutes(m)
+	return writeRoutes(*routePath, m, route1, route2)
+}
+
+// Draws the full (tiled) risk map, with both parts' routes on it.
+func (c chiton) Draw(m RiskMap, path string) error {
+	route1, route2 := c.foundRoutes(m)
+	return WriteImage(path, renderRisk(m, m.Full(), route1, route2))
+}
 
 type Point struct {
 	row int
 	col int
 }
 
-func riskForPoint(p Point) int {
-	baseRisk := input[p.row%100][p.col%100]
-	taxicabDistanceInReplicas := (p.row / 100) + (p.col / 100)
+func (m RiskMap) Risk(p Point) int {
+	baseRisk := m[p.row%len(m)][p.col%len(m[0])]
+	taxicabDistanceInReplicas := (p.row / len(m)) + (p.col / len(m[0]))
 
 	risk := baseRisk + taxicabDistanceInReplicas
 	// % 10 isn't right here because we go 9 -> 1, not 9 -> 0
@@ -198,8 +192,8 @@ func (pq SearchPriorityQueue) GetFScore(p Point) int {
 }
 
 // Minimum cost to get from (0, 0) to `target` without stepping outside the
-// rectangle formed by those two points.
-func distanceToPoint(target Point) int {
+// rectangle formed by those two points, and the route that costs that.
+func distanceToPoint(m RiskMap, target Point) (int, []Point) {
 	queue := SearchPriorityQueue{
 		heap:    []*QueueItem{},
 		byValue: map[Point]*QueueItem{},
@@ -208,6 +202,7 @@ func distanceToPoint(target Point) int {
 
 	costToPoint := map[Point]int{}
 	costToPoint[Point{row: 0, col: 0}] = 0
+	cameFrom := map[Point]Point{}
 
 	// A*: Every time we visit a point, see if the way we just
 	// came is the fastest-known way to get to any of its neighbors. If so, we
@@ -228,13 +223,14 @@ func distanceToPoint(target Point) int {
 		}
 		for _, n := range neighbors {
 			if n.row >= 0 && n.row <= target.row && n.col >= 0 && n.col <= target.col {
-				newCost := costToPoint[current.value] + riskForPoint(n)
+				newCost := costToPoint[current.value] + m.Risk(n)
 				if existingCost, exists := costToPoint[n]; !exists || newCost < existingCost {
 					log.Printf("found new best cost to %v: %d", n, newCost)
 					costToPoint[n] = newCost
+					cameFrom[n] = current.value
 
 					if n == target {
-						return newCost
+						return newCost, routeTo(cameFrom, n)
 					}
 
 					// insert or update in the priority queue
@@ -245,5 +241,5 @@ func distanceToPoint(target Point) int {
 	}
 
 	// guess we never got there...
-	return -1
+	return -1, nil
 }
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// Returned when a read runs past the end of the data, or past the end of
+	// a length-limited sub-reader.
+	ErrOutOfBits = errors.New("out of bits")
+)
+
+// Reads a stream of bits, most significant bit of each byte first.
+type BitReader struct {
+	src     io.ByteReader
+	cur     byte
+	curBits int
+
+	// Sub-readers borrow their parent's bits rather than reading from src.
+	parent *BitReader
+
+	// Both measured from the start of the outermost reader.
+	pos   int64
+	limit int64 // -1 if unlimited
+}
+
+func NewBitReader(data []byte) *BitReader {
+	return NewStreamBitReader(bytes.NewReader(data))
+}
+
+func NewStreamBitReader(r io.Reader) *BitReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &BitReader{src: br, limit: -1}
+}
+
+// Reads a hex-encoded transmission. An odd trailing digit is padded out to a
+// whole byte with zero bits.
+func NewHexBitReader(s string) (*BitReader, error) {
+	if len(s)%2 == 1 {
+		s += "0"
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex: %w", err)
+	}
+	return NewBitReader(data), nil
+}
+
+// Returns a reader for the next n bits. Reads from it advance this reader
+// too, but can't go past those n bits.
+func (r *BitReader) Limit(n int64) (*BitReader, error) {
+	if rem := r.Remaining(); rem >= 0 && n > rem {
+		return nil, fmt.Errorf("sub-reader of %d bits at bit %d: only %d remain: %w", n, r.pos, rem, ErrOutOfBits)
+	}
+	return &BitReader{parent: r, pos: r.pos, limit: r.pos + n}, nil
+}
+
+// Number of bits read so far, counting from the start of the outermost
+// reader.
+func (r *BitReader) Pos() int64 {
+	return r.pos
+}
+
+// Number of bits left before the limit, or -1 for an unlimited reader.
+func (r *BitReader) Remaining() int64 {
+	if r.limit < 0 {
+		return -1
+	}
+	return r.limit - r.pos
+}
+
+func (r *BitReader) ReadBit() (bool, error) {
+	v, err := r.ReadBits(1)
+	return v == 1, err
+}
+
+// Reads the next n (at most 64) bits as a big-endian unsigned integer.
+func (r *BitReader) ReadBits(n int) (uint64, error) {
+	if n < 0 || n > 64 {
+		return 0, fmt.Errorf("can't read %d bits at once", n)
+	}
+	if rem := r.Remaining(); rem >= 0 && int64(n) > rem {
+		return 0, fmt.Errorf("reading %d bits at bit %d: only %d remain: %w", n, r.pos, rem, ErrOutOfBits)
+	}
+
+	if r.parent != nil {
+		v, err := r.parent.ReadBits(n)
+		if err != nil {
+			return 0, err
+		}
+		r.pos += int64(n)
+		return v, nil
+	}
+
+	result := uint64(0)
+	for need := n; need > 0; {
+		if r.curBits == 0 {
+			b, err := r.src.ReadByte()
+			if err == io.EOF {
+				return 0, fmt.Errorf("reading %d bits at bit %d: %w", n, r.pos, ErrOutOfBits)
+			} else if err != nil {
+				return 0, err
+			}
+			r.cur = b
+			r.curBits = 8
+		}
+
+		take := need
+		if take > r.curBits {
+			take = r.curBits
+		}
+		chunk := (r.cur >> (r.curBits - take)) & byte(1<<take-1)
+		result = result<<take | uint64(chunk)
+		r.curBits -= take
+		r.pos += int64(take)
+		need -= take
+	}
+	return result, nil
+}
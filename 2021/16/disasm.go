@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+var typeNames = map[int64]string{
+	0: "sum",
+	1: "product",
+	2: "min",
+	3: "max",
+	4: "literal",
+	5: "gt",
+	6: "lt",
+	7: "eq",
+}
+
+var infixOperators = map[int64]string{
+	0: " + ",
+	1: " * ",
+	5: " > ",
+	6: " < ",
+	7: " == ",
+}
+
+func typeName(t int64) string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("type%d", t)
+}
+
+// Writes one line per packet: its bit offset, then its version and type
+// indented by depth, then how its subpackets were delimited.
+func disassemble(out io.Writer, p *Packet) error {
+	return disassembleAt(out, p, 0)
+}
+
+func disassembleAt(out io.Writer, p *Packet, depth int) error {
+	indent := strings.Repeat("  ", depth)
+	if p.Type == PacketLiteralType {
+		_, err := fmt.Fprintf(out, "%6d  %sv%d literal %d\n", p.Offset, indent, p.Version, p.Literal)
+		return err
+	}
+
+	length := fmt.Sprintf("%d subpackets", len(p.Subpackets))
+	if len(p.Subpackets) == 1 {
+		length = "1 subpacket"
+	}
+	if p.LengthType == LengthInBits {
+		bits := int64(0)
+		for _, s := range p.Subpackets {
+			bits += s.Bits
+		}
+		length += fmt.Sprintf(" in %d bits", bits)
+	}
+	label := fmt.Sprintf("%sv%d %s", indent, p.Version, typeName(p.Type))
+	if _, err := fmt.Fprintf(out, "%6d  %-18s %s\n", p.Offset, label, length); err != nil {
+		return err
+	}
+	for _, s := range p.Subpackets {
+		if err := disassembleAt(out, s, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Formats the packet as an S-expression, e.g. (sum (min 7 8) 9).
+func sExpression(p *Packet) string {
+	if p.Type == PacketLiteralType {
+		return fmt.Sprint(p.Literal)
+	}
+	parts := []string{typeName(p.Type)}
+	for _, s := range p.Subpackets {
+		parts = append(parts, sExpression(s))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+// Formats the packet as a conventional expression, e.g. min(7, 8) + 9.
+func infixExpression(p *Packet) string {
+	return infixAt(p, true)
+}
+
+func infixAt(p *Packet, top bool) string {
+	if p.Type == PacketLiteralType {
+		return fmt.Sprint(p.Literal)
+	}
+
+	operands := []string{}
+	if op, ok := infixOperators[p.Type]; ok && len(p.Subpackets) > 1 {
+		for _, s := range p.Subpackets {
+			operands = append(operands, infixAt(s, false))
+		}
+		expr := strings.Join(operands, op)
+		if top {
+			return expr
+		}
+		return "(" + expr + ")"
+	}
+
+	// function-call style for min/max, and for anything with too few operands
+	// to write infix
+	for _, s := range p.Subpackets {
+		operands = append(operands, infixAt(s, true))
+	}
+	return typeName(p.Type) + "(" + strings.Join(operands, ", ") + ")"
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Collects bits, most significant bit of each byte first; the counterpart of
+// BitReader.
+type BitWriter struct {
+	buf []byte
+	len int64
+}
+
+func (w *BitWriter) WriteBits(v uint64, n int) error {
+	if n < 0 || n > 64 {
+		return fmt.Errorf("can't write %d bits at once", n)
+	}
+	if n < 64 && v>>n != 0 {
+		return fmt.Errorf("%d doesn't fit in %d bits", v, n)
+	}
+	for i := n - 1; i >= 0; i-- {
+		if w.len%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		if v>>i&1 == 1 {
+			w.buf[len(w.buf)-1] |= 1 << (7 - w.len%8)
+		}
+		w.len++
+	}
+	return nil
+}
+
+func (w *BitWriter) WriteBit(b bool) error {
+	if b {
+		return w.WriteBits(1, 1)
+	}
+	return w.WriteBits(0, 1)
+}
+
+// Appends everything written to other.
+func (w *BitWriter) Append(other *BitWriter) error {
+	r := NewBitReader(other.buf)
+	for remaining := other.len; remaining > 0; {
+		n := 64
+		if remaining < 64 {
+			n = int(remaining)
+		}
+		v, err := r.ReadBits(n)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteBits(v, n); err != nil {
+			return err
+		}
+		remaining -= int64(n)
+	}
+	return nil
+}
+
+// Number of bits written.
+func (w *BitWriter) Len() int64 {
+	return w.len
+}
+
+// The bits written so far, with the last byte padded with zeros.
+func (w *BitWriter) Bytes() []byte {
+	return w.buf
+}
+
+func (w *BitWriter) Hex() string {
+	return strings.ToUpper(hex.EncodeToString(w.buf))
+}
+
+func encodePacket(w *BitWriter, p *Packet) error {
+	if p.Version < 0 || p.Version > 7 {
+		return fmt.Errorf("version %d doesn't fit in 3 bits", p.Version)
+	}
+	if p.Type < 0 || p.Type > 7 {
+		return fmt.Errorf("type %d doesn't fit in 3 bits", p.Type)
+	}
+	if err := w.WriteBits(uint64(p.Version), 3); err != nil {
+		return err
+	}
+	if err := w.WriteBits(uint64(p.Type), 3); err != nil {
+		return err
+	}
+
+	if p.Type == PacketLiteralType {
+		if len(p.Subpackets) > 0 {
+			return fmt.Errorf("literal packet with %d subpackets", len(p.Subpackets))
+		}
+		if p.Literal < 0 {
+			return fmt.Errorf("can't encode negative literal %d", p.Literal)
+		}
+		nibbles := 1
+		for v := p.Literal >> 4; v > 0; v >>= 4 {
+			nibbles++
+		}
+		for i := nibbles - 1; i >= 0; i-- {
+			if err := w.WriteBit(i > 0); err != nil {
+				return err
+			}
+			if err := w.WriteBits(uint64(p.Literal>>(4*i))&0xF, 4); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch p.LengthType {
+	case LengthInBits:
+		sub := &BitWriter{}
+		for _, s := range p.Subpackets {
+			if err := encodePacket(sub, s); err != nil {
+				return err
+			}
+		}
+		if sub.Len() >= 1<<15 {
+			return fmt.Errorf("subpackets take %d bits, more than a 15-bit length allows", sub.Len())
+		}
+		if err := w.WriteBit(false); err != nil {
+			return err
+		}
+		if err := w.WriteBits(uint64(sub.Len()), 15); err != nil {
+			return err
+		}
+		return w.Append(sub)
+
+	case LengthInPackets:
+		if len(p.Subpackets) >= 1<<11 {
+			return fmt.Errorf("%d subpackets is more than an 11-bit count allows", len(p.Subpackets))
+		}
+		if err := w.WriteBit(true); err != nil {
+			return err
+		}
+		if err := w.WriteBits(uint64(len(p.Subpackets)), 11); err != nil {
+			return err
+		}
+		for _, s := range p.Subpackets {
+			if err := encodePacket(w, s); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown subpacket length type %d", p.LengthType)
+	}
+}
+
+// Inverse of parseTransmission: encodes the packet (using each operator's
+// LengthType) as hex, zero-padded to a whole byte.
+func encodeTransmission(p *Packet) (string, error) {
+	w := &BitWriter{}
+	if err := encodePacket(w, p); err != nil {
+		return "", err
+	}
+	return w.Hex(), nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrOverflow = errors.New("int64 overflow")
+)
+
+type Evaluator struct {
+	// If set, every operator writes a line showing its operands and result.
+	Trace io.Writer
+
+	// If set, a packet whose evaluation overflows int64 is evaluated again
+	// with math/big instead of failing with ErrOverflow.
+	BigFallback bool
+}
+
+func (e Evaluator) Eval(p *Packet) (*big.Int, error) {
+	v, err := e.evalInt(p, 0)
+	if err == nil {
+		return big.NewInt(v), nil
+	}
+	if !errors.Is(err, ErrOverflow) || !e.BigFallback {
+		return nil, err
+	}
+	e.tracef(0, "%v; retrying with math/big", err)
+	return e.evalBig(p, 0)
+}
+
+func (e Evaluator) tracef(depth int, format string, args ...interface{}) {
+	if e.Trace != nil {
+		fmt.Fprintf(e.Trace, strings.Repeat("  ", depth)+format+"\n", args...)
+	}
+}
+
+func (e Evaluator) traceOp(depth int, p *Packet, operands []string, result interface{}) {
+	e.tracef(depth, "%s(%s) = %v", typeName(p.Type), strings.Join(operands, ", "), result)
+}
+
+// Checks the things about a packet's shape that evaluation relies on.
+func checkOperands(p *Packet) error {
+	switch p.Type {
+	case 0, 1:
+		return nil
+	case 2, 3:
+		if len(p.Subpackets) == 0 {
+			return fmt.Errorf("%s packet at bit %d has no subpackets", typeName(p.Type), p.Offset)
+		}
+	case PacketLiteralType:
+		if len(p.Subpackets) != 0 {
+			return fmt.Errorf("literal packet at bit %d has %d subpackets", p.Offset, len(p.Subpackets))
+		}
+	case 5, 6, 7:
+		if len(p.Subpackets) != 2 {
+			return fmt.Errorf("%s packet at bit %d has %d subpackets", typeName(p.Type), p.Offset, len(p.Subpackets))
+		}
+	default:
+		return fmt.Errorf("unknown op type %d at bit %d", p.Type, p.Offset)
+	}
+	return nil
+}
+
+func addInt(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, ErrOverflow)
+	}
+	return a + b, nil
+}
+
+func mulInt(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	r := a * b
+	if r/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("%d * %d: %w", a, b, ErrOverflow)
+	}
+	return r, nil
+}
+
+func (e Evaluator) evalInt(p *Packet, depth int) (int64, error) {
+	if err := checkOperands(p); err != nil {
+		return 0, err
+	}
+	if p.Type == PacketLiteralType {
+		return p.Literal, nil
+	}
+
+	operands := make([]int64, len(p.Subpackets))
+	for i, s := range p.Subpackets {
+		var err error
+		if operands[i], err = e.evalInt(s, depth+1); err != nil {
+			return 0, err
+		}
+	}
+
+	var result int64
+	var err error
+	switch p.Type {
+	case 0: // sum
+		result = 0
+		for _, v := range operands {
+			if result, err = addInt(result, v); err != nil {
+				return 0, fmt.Errorf("sum at bit %d: %w", p.Offset, err)
+			}
+		}
+	case 1: // product
+		result = 1
+		for _, v := range operands {
+			if result, err = mulInt(result, v); err != nil {
+				return 0, fmt.Errorf("product at bit %d: %w", p.Offset, err)
+			}
+		}
+	case 2: // minimum
+		result = operands[0]
+		for _, v := range operands[1:] {
+			if v < result {
+				result = v
+			}
+		}
+	case 3: // maximum
+		result = operands[0]
+		for _, v := range operands[1:] {
+			if v > result {
+				result = v
+			}
+		}
+	case 5: // greater-than
+		if operands[0] > operands[1] {
+			result = 1
+		}
+	case 6: // less-than
+		if operands[0] < operands[1] {
+			result = 1
+		}
+	case 7: // equal
+		if operands[0] == operands[1] {
+			result = 1
+		}
+	}
+
+	if e.Trace != nil {
+		strs := make([]string, len(operands))
+		for i, v := range operands {
+			strs[i] = fmt.Sprint(v)
+		}
+		e.traceOp(depth, p, strs, result)
+	}
+	return result, nil
+}
+
+func (e Evaluator) evalBig(p *Packet, depth int) (*big.Int, error) {
+	if err := checkOperands(p); err != nil {
+		return nil, err
+	}
+	if p.Type == PacketLiteralType {
+		return big.NewInt(p.Literal), nil
+	}
+
+	operands := make([]*big.Int, len(p.Subpackets))
+	for i, s := range p.Subpackets {
+		var err error
+		if operands[i], err = e.evalBig(s, depth+1); err != nil {
+			return nil, err
+		}
+	}
+
+	result := new(big.Int)
+	switch p.Type {
+	case 0: // sum
+		for _, v := range operands {
+			result.Add(result, v)
+		}
+	case 1: // product
+		result.SetInt64(1)
+		for _, v := range operands {
+			result.Mul(result, v)
+		}
+	case 2: // minimum
+		result.Set(operands[0])
+		for _, v := range operands[1:] {
+			if v.Cmp(result) < 0 {
+				result.Set(v)
+			}
+		}
+	case 3: // maximum
+		result.Set(operands[0])
+		for _, v := range operands[1:] {
+			if v.Cmp(result) > 0 {
+				result.Set(v)
+			}
+		}
+	case 5: // greater-than
+		if operands[0].Cmp(operands[1]) > 0 {
+			result.SetInt64(1)
+		}
+	case 6: // less-than
+		if operands[0].Cmp(operands[1]) < 0 {
+			result.SetInt64(1)
+		}
+	case 7: // equal
+		if operands[0].Cmp(operands[1]) == 0 {
+			result.SetInt64(1)
+		}
+	}
+
+	if e.Trace != nil {
+		strs := make([]string, len(operands))
+		for i, v := range operands {
+			strs[i] = v.String()
+		}
+		e.traceOp(depth, p, strs, result)
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+// Builds a random packet tree at most maxDepth operators deep.
+func randomPacket(rng *rand.Rand, maxDepth int) *Packet {
+	p := &Packet{
+		Version: rng.Int63n(8),
+	}
+	if maxDepth == 0 || rng.Intn(3) == 0 {
+		p.Type = PacketLiteralType
+		// mostly small literals, but sometimes all 63 bits
+		if rng.Intn(4) == 0 {
+			p.Literal = rng.Int63()
+		} else {
+			p.Literal = rng.Int63n(1 << uint(rng.Intn(20)+1))
+		}
+		return p
+	}
+
+	p.Type = rng.Int63n(7)
+	if p.Type >= PacketLiteralType {
+		p.Type++
+	}
+	p.LengthType = LengthType(rng.Intn(2))
+	p.Subpackets = []*Packet{}
+	for n := rng.Intn(5); n > 0; n-- {
+		p.Subpackets = append(p.Subpackets, randomPacket(rng, maxDepth-1))
+	}
+	return p
+}
+
+func clearPositions(p *Packet) {
+	p.Offset = 0
+	p.Bits = 0
+	for _, s := range p.Subpackets {
+		clearPositions(s)
+	}
+}
+
+var errPanicked = errors.New("parser panicked")
+
+// Parses hex, turning a panic into errPanicked so that a crash on malformed
+// input is reported like any other failure.
+func parseWithoutPanic(hex string) (p *Packet, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errPanicked, r)
+		}
+	}()
+	return parseTransmission(hex)
+}
+
+// Checks that random packet trees survive encode->decode unchanged, and that
+// truncated or corrupted transmissions fail cleanly.
+func fuzzRoundTrip(rng *rand.Rand, iterations int) error {
+	for i := 0; i < iterations; i++ {
+		want := randomPacket(rng, 4)
+		w := &BitWriter{}
+		if err := encodePacket(w, want); err != nil {
+			// too many subpacket bits for a 15-bit length, most likely
+			continue
+		}
+		encoded := w.Hex()
+
+		got, err := parseWithoutPanic(encoded)
+		if err != nil {
+			return fmt.Errorf("iteration %d: decoding %s: %w", i, encoded, err)
+		}
+		if got.Bits != w.Len() {
+			return fmt.Errorf("iteration %d: %s decoded from %d bits, but encoded in %d", i, encoded, got.Bits, w.Len())
+		}
+		clearPositions(got)
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("iteration %d: %s decoded to %+v, want %+v", i, encoded, got, want)
+		}
+
+		// Cutting off a byte that holds part of the packet must be an error.
+		// (An odd number of digits would get padded with zeros, which might
+		// happen to make a different valid packet.)
+		cut := 2 * rng.Intn(int(w.Len()-1)/8+1)
+		if _, err := parseWithoutPanic(encoded[:cut]); err == nil || errors.Is(err, errPanicked) {
+			return fmt.Errorf("iteration %d: truncating %s to %q: got err %v", i, encoded, encoded[:cut], err)
+		}
+
+		// Flipped digits may or may not still decode, but mustn't crash.
+		corrupted := []byte(encoded)
+		for n := rng.Intn(3) + 1; n > 0; n-- {
+			corrupted[rng.Intn(len(corrupted))] = "0123456789ABCDEF"[rng.Intn(16)]
+		}
+		if _, err := parseWithoutPanic(string(corrupted)); errors.Is(err, errPanicked) {
+			return fmt.Errorf("iteration %d: corrupting %s to %s: %w", i, encoded, corrupted, err)
+		}
+
+		// Nor should garbage.
+		garbage := strings.Repeat("G", rng.Intn(2)) + encoded[:rng.Intn(len(encoded))]
+		if _, err := parseWithoutPanic(garbage); errors.Is(err, errPanicked) {
+			return fmt.Errorf("iteration %d: parsing %q: %w", i, garbage, err)
+		}
+	}
+	return nil
+}
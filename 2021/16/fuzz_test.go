@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Each input seeds a run of fuzzRoundTrip, so the fuzzer explores random
+// packet trees through the same checks as -fuzz.
+func FuzzRoundTrip(f *testing.F) {
+	for _, seed := range []int64{1, 2, 3, 42} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		if err := fuzzRoundTrip(rand.New(rand.NewSource(seed)), 10); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// Any hex at all must parse or fail cleanly, and whatever parses must encode
+// back to a transmission that parses to the same packets.
+func FuzzParse(f *testing.F) {
+	for _, hex := range []string{
+		"D2FE28",
+		"38006F45291200",
+		"EE00D40C823060",
+		"8A004A801A8002F478",
+		"9C0141080250320F1802104A08",
+		"",
+		"G",
+	} {
+		f.Add(hex)
+	}
+	f.Fuzz(func(t *testing.T, hex string) {
+		p, err := parseWithoutPanic(hex)
+		if errors.Is(err, errPanicked) {
+			t.Fatalf("parsing %q: %v", hex, err)
+		}
+		if err != nil {
+			return
+		}
+		encoded, err := encodeTransmission(p)
+		if err != nil {
+			t.Fatalf("re-encoding %q: %v", hex, err)
+		}
+		again, err := parseWithoutPanic(encoded)
+		if err != nil {
+			t.Fatalf("parsing %q, re-encoded from %q: %v", encoded, hex, err)
+		}
+		clearPositions(p)
+		clearPositions(again)
+		if !reflect.DeepEqual(p, again) {
+			t.Fatalf("%q parsed to %+v, but re-encoded as %q it parses to %+v", hex, p, encoded, again)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Gives every operator in a random tree the number of operands it needs:
+// at least one for sums, products, minimums and maximums, exactly two for
+// comparisons.
+func fixOperands(rng *rand.Rand, p *Packet) {
+	for _, sub := range p.Subpackets {
+		fixOperands(rng, sub)
+	}
+	switch p.Type {
+	case 0, 1, 2, 3:
+		if len(p.Subpackets) == 0 {
+			p.Subpackets = append(p.Subpackets, randomPacket(rng, 0))
+		}
+	case 5, 6, 7:
+		for len(p.Subpackets) < 2 {
+			p.Subpackets = append(p.Subpackets, randomPacket(rng, 0))
+		}
+		p.Subpackets = p.Subpackets[:2]
+	}
+}
+
+// Switches any operator whose subpackets take too many bits for a 15-bit
+// length to counting them instead.
+func fitLengths(p *Packet) error {
+	for _, sub := range p.Subpackets {
+		if err := fitLengths(sub); err != nil {
+			return err
+		}
+	}
+	if p.Type == PacketLiteralType || p.LengthType != LengthInBits {
+		return nil
+	}
+	sub := &BitWriter{}
+	for _, s := range p.Subpackets {
+		if err := encodePacket(sub, s); err != nil {
+			return err
+		}
+	}
+	if sub.Len() >= 1<<15 {
+		p.LengthType = LengthInPackets
+	}
+	return nil
+}
+
+// A random transmission, as hex, that parses and evaluates without
+// overflowing int64: a valid puzzle input. The outermost packet is always an
+// operator, since a lone literal makes for a trivial puzzle.
+func generateTransmission(rng *rand.Rand, maxDepth int) (string, error) {
+	if maxDepth < 1 {
+		return "", fmt.Errorf("depth must be at least 1, for the outermost operator")
+	}
+	for tries := 0; tries < 1000; tries++ {
+		p := randomPacket(rng, maxDepth)
+		if p.Type == PacketLiteralType {
+			continue
+		}
+		fixOperands(rng, p)
+		if _, err := (Evaluator{}).Eval(p); err != nil {
+			continue
+		}
+		if err := fitLengths(p); err != nil {
+			return "", err
+		}
+		return encodeTransmission(p)
+	}
+	return "", fmt.Errorf("every packet tree up to depth %d overflowed", maxDepth)
+}
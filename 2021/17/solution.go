@@ -1,20 +1,91 @@
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+)
 
-func main() {
-	fmt.Printf("Part 1 solution: %d\n", part1())
+type scanner struct {
+	file *os.File
+	sc   *bufio.Scanner
+	err  error
+}
 
-	fmt.Printf("Part 2 solution: %d\n", part2())
+func (s *scanner) NextLine() (string, bool) {
+	if s.sc.Scan() {
+		return s.sc.Text(), true
+	} else {
+		return "", false
+	}
+}
+
+func (s *scanner) Finish() error {
+	if s.sc != nil {
+		s.err = s.sc.Err()
+
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
+		s.sc = nil
+	}
+	return s.err
 }
 
-const (
-	minX = 257
-	maxX = 286
-	minY = -101
-	maxY = -57
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
+}
+
+func main() {
+	if err := RunSolver[Target](trickShot{}); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var (
+	inputFormat = regexp.MustCompile("target area: x=(?P<iMinX>-?\\d+)\\.\\.(?P<iMaxX>-?\\d+), y=(?P<iMinY>-?\\d+)\\.\\.(?P<iMaxY>-?\\d+)")
 )
 
+type parsedParams struct {
+	FullMatch string
+	Strings   map[string]string
+	Numbers   map[string]int
+}
+
+func extractRegexp(pattern *regexp.Regexp, str string) (*parsedParams, error) {
+	params := &parsedParams{
+		Strings: make(map[string]string),
+		Numbers: make(map[string]int),
+	}
+
+	subMatches := pattern.FindStringSubmatch(str)
+	if len(subMatches) != len(pattern.SubexpNames()) {
+		return nil, fmt.Errorf("%s does not match pattern %s", str, pattern)
+	}
+	for i, name := range pattern.SubexpNames() {
+		if name == "" {
+			params.FullMatch = subMatches[i]
+		} else if name[0] == 's' {
+			params.Strings[name[1:]] = subMatches[i]
+		} else if name[0] == 'i' {
+			num, err := strconv.Atoi(subMatches[i])
+			if err != nil {
+				return nil, err
+			}
+			params.Numbers[name[1:]] = num
+		} else {
+			return nil, fmt.Errorf("unknown parse type for capture group %s", name)
+		}
+	}
+
+	return params, nil
+}
+
 type Velocity struct {
 	X int
 	Y int
@@ -25,6 +96,44 @@ type Point struct {
 	Y int
 }
 
+type Target struct {
+	MinX int
+	MaxX int
+	MinY int
+	MaxY int
+}
+
+func (t Target) Contains(p Point) bool {
+	return p.X >= t.MinX && p.X <= t.MaxX && p.Y >= t.MinY && p.Y <= t.MaxY
+}
+
+type trickShot struct{}
+
+func (trickShot) Parse(input io.Reader) (Target, error) {
+	scanner := newScanner(input)
+	line, ok := scanner.NextLine()
+	if err := scanner.Finish(); err != nil {
+		return Target{}, err
+	}
+	if !ok {
+		return Target{}, fmt.Errorf("input is empty")
+	}
+	parsedLine, err := extractRegexp(inputFormat, line)
+	if err != nil {
+		return Target{}, err
+	}
+	t := Target{
+		MinX: parsedLine.Numbers["MinX"],
+		MaxX: parsedLine.Numbers["MaxX"],
+		MinY: parsedLine.Numbers["MinY"],
+		MaxY: parsedLine.Numbers["MaxY"],
+	}
+	if t.MinX > t.MaxX || t.MinY > t.MaxY {
+		return Target{}, fmt.Errorf("target area %+v is empty", t)
+	}
+	return t, nil
+}
+
 func slow(xVelo int) int {
 	if xVelo < 0 {
 		return xVelo + 1
@@ -34,24 +143,139 @@ func slow(xVelo int) int {
 		return xVelo
 	}
 }
+
 func NextStep(p Point, v Velocity) (Point, Velocity) {
 	return Point{
 		X: p.X + v.X,
 		Y: p.Y + v.Y,
-	}, Velocity {
+	}, Velocity{
 		X: slow(v.X),
 		Y: v.Y - 1,
 	}
 }
 
-func part1() int {
-	bestYMax := -200
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
 
-	// maximize y subject to x constraint
-	for dx := 1; dx < maxX; dx++ {
-		for dy := -101; dy minY; dx < maxX && dy <  ; xVeloGuess < maxX && yVeloGuess > 
-	return 0
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Where a probe launched with this sideways speed comes to a stop.
+func restingX(dx int) int {
+	if dx < 0 {
+		return -restingX(-dx)
+	}
+	return dx * (dx + 1) / 2
 }
-func part2() int {
-	return 0
+
+// Any probe launched upwards comes back down through its launch height
+// exactly (see velocityBounds), so if that's level with the target and the
+// probe stops sideways above it, it'll hit however high it's thrown.
+func (t Target) unboundedHits() (int, bool) {
+	if t.MinY > 0 || t.MaxY < 0 {
+		return 0, false
+	}
+	lo, hi := t.velocityBounds()
+	for dx := lo.X; dx <= hi.X; dx++ {
+		if x := restingX(dx); x >= t.MinX && x <= t.MaxX {
+			return dx, true
+		}
+	}
+	return 0, false
+}
+
+// The only initial velocities that could reach the target. Any faster
+// sideways, and the first step overshoots it. Any faster downwards, and the
+// first step falls past it. A probe launched upwards at dy passes back down
+// through the heights it went up through, then steps from dy straight to 0
+// and then to -(dy+1). So it can't drop into a target above the launcher once
+// it's gone over it, and it can't go deeper than -(dy+1) without overshooting
+// a target below. If the target's level with the launcher, the probe is there
+// after 2*dy+1 steps; launching higher than that can only hit if it's stopped
+// over the target sideways, which unboundedHits checks for separately.
+func (t Target) velocityBounds() (Velocity, Velocity) {
+	lo := Velocity{X: min(t.MinX, 0), Y: min(t.MinY, 0)}
+	hi := Velocity{X: max(t.MaxX, 0), Y: max(t.MaxY, -t.MinY-1)}
+	if t.MinY <= 0 && t.MaxY >= 0 {
+		hi.Y = max(hi.Y, max(-lo.X, hi.X)/2+1)
+	}
+	return lo, hi
+}
+
+// Whether the probe ever lands in the target after a whole number of steps.
+func (t Target) Hits(v Velocity) bool {
+	p := Point{}
+	for {
+		p, v = NextStep(p, v)
+		if t.Contains(p) {
+			return true
+		}
+		// give up once the probe can't get back to the target
+		if p.Y < t.MinY && v.Y < 0 {
+			return false
+		}
+		if (p.X > t.MaxX && v.X >= 0) || (p.X < t.MinX && v.X <= 0) {
+			return false
+		}
+	}
+}
+
+// Every initial velocity that hits the target, and the highest point any of
+// them reaches.
+func (t Target) Solve() ([]Velocity, int, error) {
+	if dx, ok := t.unboundedHits(); ok {
+		return nil, 0, fmt.Errorf("target %+v is level with the launcher, so a probe launched with x velocity %d hits it at any height", t, dx)
+	}
+
+	lo, hi := t.velocityBounds()
+	hits := []Velocity{}
+	bestYMax := 0
+	for dx := lo.X; dx <= hi.X; dx++ {
+		for dy := lo.Y; dy <= hi.Y; dy++ {
+			v := Velocity{X: dx, Y: dy}
+			if !t.Hits(v) {
+				continue
+			}
+			if len(hits) == 0 || apex(dy) > bestYMax {
+				bestYMax = apex(dy)
+			}
+			hits = append(hits, v)
+		}
+	}
+	if len(hits) == 0 {
+		return nil, 0, fmt.Errorf("nothing hits target %+v", t)
+	}
+	return hits, bestYMax, nil
+}
+
+// Highest point of a probe launched with this upward speed.
+func apex(dy int) int {
+	if dy <= 0 {
+		return 0
+	}
+	return dy * (dy + 1) / 2
+}
+
+func (trickShot) Part1(t Target) (Answer, error) {
+	_, bestYMax, err := t.Solve()
+	if err != nil {
+		return nil, err
+	}
+	return bestYMax, nil
+}
+
+func (trickShot) Part2(t Target) (Answer, error) {
+	hits, _, err := t.Solve()
+	if err != nil {
+		return nil, err
+	}
+	return len(hits), nil
 }
@@ -2,12 +2,12 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 )
 
@@ -26,38 +26,51 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
+var (
+	trace          = flag.Bool("trace", false, "print every step as it runs")
+	showTrajectory = flag.Bool("trajectory", false, "print every position the submarine passes through")
+	semanticsName  = flag.String("semantics", "", "instead of solving, run the course with these semantics (one of: "+semanticsNames()+")")
+)
+
 func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
+	flag.Parse()
+	if *semanticsName != "" {
+		sem, ok := semanticsByName[*semanticsName]
+		if !ok {
+			log.Fatalf("unknown semantics %q; want one of: %s", *semanticsName, semanticsNames())
+		}
+		program, err := readInput[[]Instruction](dive{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		final := runCourse(program, sem)
+		fmt.Printf("Final position: %v (x*depth = %d)\n", final, final.X*final.Depth)
+		return
+	}
 
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+	if err := RunSolver[[]Instruction](dive{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var (
-	inputFormat = regexp.MustCompile("(?P<sDir>\\w+) (?P<iDistance>\\d+)")
+	inputFormat = regexp.MustCompile("^(?P<sDir>\\w+) (?P<iDistance>\\d+)$")
 )
 
 type parsedParams struct {
@@ -95,68 +108,48 @@ func extractRegexp(pattern *regexp.Regexp, str string) (*parsedParams, error) {
 	return params, nil
 }
 
-func part1(input *scanner) int {
-	// Setup
-	count := 0
-	x := 0
-	y := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		parsedLine, err := extractRegexp(inputFormat, line)
-		if err != nil {
-			log.Fatalf("input line %d: %v", count, err)
-		}
+type dive struct{}
 
-		// Process line
-		switch parsedLine.Strings["Dir"] {
-		case "forward":
-			x += parsedLine.Numbers["Distance"]
-		case "up":
-			y -= parsedLine.Numbers["Distance"]
-			if y < 0 {
-				y = 0
-			}
-		case "down":
-			y += parsedLine.Numbers["Distance"]
+func (dive) Parse(input io.Reader) ([]Instruction, error) {
+	scanner := newScanner(input)
+	lineNo := 1
+	program := []Instruction{}
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
+		in, err := ParseInstruction(line)
+		if err != nil {
+			return nil, fmt.Errorf("input line %d: %w", lineNo, err)
 		}
-		count++
+		program = append(program, in)
+		lineNo++
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	if err := scanner.Finish(); err != nil {
+		return nil, err
 	}
-	return x * y
+	return program, nil
 }
 
-func part2(input *scanner) int {
-	// Setup
-	count := 0
-	x := 0
-	y := 0
-	aim := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		parsedLine, err := extractRegexp(inputFormat, line)
-		if err != nil {
-			log.Fatalf("input line %d: %v", count, err)
-		}
-
-		// Process line
-		switch parsedLine.Strings["Dir"] {
-		case "forward":
-			x += parsedLine.Numbers["Distance"]
-			y += (aim * parsedLine.Numbers["Distance"])
-			if y < 0 {
-				y = 0
-			}
-		case "up":
-			aim -= parsedLine.Numbers["Distance"]
-		case "down":
-			aim += parsedLine.Numbers["Distance"]
-		}
-		count++
+// Runs the whole course, printing steps and positions if flags ask for them,
+// and returns where it ends up.
+func runCourse(program []Instruction, sem Semantics) State {
+	var traceTo io.Writer
+	if *trace {
+		traceTo = os.Stderr
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	trajectory := Run(program, sem, traceTo)
+	if *showTrajectory {
+		for i, s := range trajectory {
+			fmt.Printf("%4d  %v\n", i, s)
+		}
 	}
-	return x * y
+	return trajectory[len(trajectory)-1]
+}
+
+func (dive) Part1(program []Instruction) (Answer, error) {
+	final := runCourse(program, Plain)
+	return final.X * final.Depth, nil
+}
+
+func (dive) Part2(program []Instruction) (Answer, error) {
+	final := runCourse(program, Aimed)
+	return final.X * final.Depth, nil
 }
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Op int
+
+const (
+	Forward Op = iota
+	Up
+	Down
+)
+
+var opNames = map[string]Op{
+	"forward": Forward,
+	"up":      Up,
+	"down":    Down,
+}
+
+func (o Op) String() string {
+	for name, op := range opNames {
+		if op == o {
+			return name
+		}
+	}
+	return "err"
+}
+
+type Instruction struct {
+	Op  Op
+	Arg int
+}
+
+func (in Instruction) String() string {
+	return fmt.Sprintf("%s %d", in.Op, in.Arg)
+}
+
+// Parses one line of the form "forward 5".
+func ParseInstruction(line string) (Instruction, error) {
+	parsedLine, err := extractRegexp(inputFormat, line)
+	if err != nil {
+		return Instruction{}, err
+	}
+	op, ok := opNames[parsedLine.Strings["Dir"]]
+	if !ok {
+		return Instruction{}, fmt.Errorf("unknown instruction %q", parsedLine.Strings["Dir"])
+	}
+	return Instruction{Op: op, Arg: parsedLine.Numbers["Distance"]}, nil
+}
+
+type State struct {
+	X     int
+	Depth int
+	Aim   int
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("x=%d depth=%d aim=%d", s.X, s.Depth, s.Aim)
+}
+
+// What an instruction does to the submarine.
+type Semantics func(s State, in Instruction) State
+
+// up and down move the submarine directly.
+func Plain(s State, in Instruction) State {
+	switch in.Op {
+	case Forward:
+		s.X += in.Arg
+	case Up:
+		s.Depth -= in.Arg
+	case Down:
+		s.Depth += in.Arg
+	}
+	return s
+}
+
+// up and down tilt the submarine, and forward moves along the tilt.
+func Aimed(s State, in Instruction) State {
+	switch in.Op {
+	case Forward:
+		s.X += in.Arg
+		s.Depth += s.Aim * in.Arg
+	case Up:
+		s.Aim -= in.Arg
+	case Down:
+		s.Aim += in.Arg
+	}
+	return s
+}
+
+// Wraps other semantics so that the submarine can't rise above the surface.
+func ClampToSurface(sem Semantics) Semantics {
+	return func(s State, in Instruction) State {
+		s = sem(s, in)
+		if s.Depth < 0 {
+			s.Depth = 0
+		}
+		return s
+	}
+}
+
+var semanticsByName = map[string]Semantics{
+	"plain":         Plain,
+	"aim":           Aimed,
+	"plain-clamped": ClampToSurface(Plain),
+	"aim-clamped":   ClampToSurface(Aimed),
+}
+
+func semanticsNames() string {
+	names := []string{}
+	for name := range semanticsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Runs the program from the origin, and returns every state it passes
+// through, starting with the origin. If trace is set, each step is written to
+// it.
+func Run(program []Instruction, sem Semantics, trace io.Writer) []State {
+	trajectory := make([]State, 0, len(program)+1)
+	s := State{}
+	trajectory = append(trajectory, s)
+	for i, in := range program {
+		next := sem(s, in)
+		if trace != nil {
+			fmt.Fprintf(trace, "%4d  %-12s %v -> %v\n", i, in, s, next)
+		}
+		s = next
+		trajectory = append(trajectory, s)
+	}
+	return trajectory
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Binary words of equal width, kept as strings of '0' and '1' so that width
+// isn't limited by any integer type. Column 0 is the leftmost (most
+// significant) bit.
+type Words struct {
+	Width int
+	words []string
+}
+
+func (w *Words) Add(word string) error {
+	if len(w.words) == 0 {
+		w.Width = len(word)
+	} else if len(word) != w.Width {
+		return fmt.Errorf("%q is %d bits wide, but earlier words were %d", word, len(word), w.Width)
+	}
+	for _, c := range word {
+		if c != '0' && c != '1' {
+			return fmt.Errorf("%q isn't binary", word)
+		}
+	}
+	w.words = append(w.words, word)
+	return nil
+}
+
+func (w *Words) Len() int {
+	return len(w.words)
+}
+
+// How many words have a 1 in each column.
+func (w *Words) OneCounts() []int {
+	ones := make([]int, w.Width)
+	for _, word := range w.words {
+		for i := 0; i < w.Width; i++ {
+			if word[i] == '1' {
+				ones[i]++
+			}
+		}
+	}
+	return ones
+}
+
+type Criterion int
+
+const (
+	MostCommon Criterion = iota
+	LeastCommon
+)
+
+// Which bit wins when a column has as many 0s as 1s.
+type TiePolicy int
+
+const (
+	PreferOne TiePolicy = iota
+	PreferZero
+)
+
+func pick(ones int, total int, c Criterion, tie TiePolicy) byte {
+	zeros := total - ones
+	if ones == zeros {
+		if tie == PreferOne {
+			return '1'
+		}
+		return '0'
+	}
+	if (ones > zeros) == (c == MostCommon) {
+		return '1'
+	}
+	return '0'
+}
+
+// The most (or least) common bit in each column.
+func (w *Words) CommonBits(c Criterion, tie TiePolicy) string {
+	result := make([]byte, w.Width)
+	for i, ones := range w.OneCounts() {
+		result[i] = pick(ones, len(w.words), c, tie)
+	}
+	return string(result)
+}
+
+// Narrows the words down one column at a time, keeping those with the most
+// (or least) common bit among the words still left, until one remains. A
+// column where every remaining word has the same bit doesn't narrow anything,
+// even when looking for the least common bit.
+func (w *Words) Filter(c Criterion, tie TiePolicy) (string, error) {
+	if len(w.words) == 0 {
+		return "", fmt.Errorf("no words to filter")
+	}
+	remaining := w.words
+	for col := 0; col < w.Width && len(remaining) > 1; col++ {
+		ones := 0
+		for _, word := range remaining {
+			if word[col] == '1' {
+				ones++
+			}
+		}
+		if ones == 0 || ones == len(remaining) {
+			continue
+		}
+
+		keep := pick(ones, len(remaining), c, tie)
+		next := make([]string, 0, len(remaining))
+		for _, word := range remaining {
+			if word[col] == keep {
+				next = append(next, word)
+			}
+		}
+		remaining = next
+	}
+	// anything left over now is a duplicate of the same word.
+	return remaining[0], nil
+}
+
+func wordValue(word string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(word, 2)
+	if !ok {
+		return nil, fmt.Errorf("%q isn't binary", word)
+	}
+	return v, nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Board struct {
+	Id      int
+	Size    int
+	Numbers [][]int64
+}
+
+func NewBoard(id int, size int) *Board {
+	nums := make([][]int64, size)
+	for i := 0; i < size; i++ {
+		nums[i] = make([]int64, size)
+	}
+	return &Board{
+		Id:      id,
+		Size:    size,
+		Numbers: nums,
+	}
+}
+
+type Game struct {
+	Calls  []int64
+	Boards []*Board
+}
+
+var (
+	whitespace = regexp.MustCompile("\\s+")
+)
+
+// Reads the called numbers from the first line, then square boards separated
+// by blank lines.
+func ParseGame(input *scanner) (*Game, error) {
+	firstLine, ok := input.NextLine()
+	if !ok {
+		if err := input.Finish(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("input is empty; expected a line of numbers to call")
+	}
+	game := &Game{}
+	for _, n := range strings.Split(strings.TrimSpace(firstLine), ",") {
+		parsed, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line 1: %w", err)
+		}
+		game.Calls = append(game.Calls, parsed)
+	}
+
+	lineNo := 1
+	lineInBoard := 0
+	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
+		lineNo++
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			if lineInBoard != 0 && lineInBoard != game.Boards[len(game.Boards)-1].Size {
+				return nil, fmt.Errorf("line %d: board %d has %d rows, want %d", lineNo, len(game.Boards)-1, lineInBoard, game.Boards[len(game.Boards)-1].Size)
+			}
+			lineInBoard = 0
+			continue
+		}
+
+		boardLine := whitespace.Split(line, -1)
+		if lineInBoard == 0 {
+			game.Boards = append(game.Boards, NewBoard(len(game.Boards), len(boardLine)))
+		}
+		b := game.Boards[len(game.Boards)-1]
+		if len(boardLine) != b.Size || lineInBoard >= b.Size {
+			return nil, fmt.Errorf("line %d: board %d isn't %dx%d", lineNo, b.Id, b.Size, b.Size)
+		}
+		for i, n := range boardLine {
+			parsed, err := strconv.ParseInt(n, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			b.Numbers[lineInBoard][i] = parsed
+		}
+		lineInBoard++
+	}
+	if err := input.Finish(); err != nil {
+		return nil, err
+	}
+	if lineInBoard != 0 && lineInBoard != game.Boards[len(game.Boards)-1].Size {
+		return nil, fmt.Errorf("last board has %d rows, want %d", lineInBoard, game.Boards[len(game.Boards)-1].Size)
+	}
+	return game, nil
+}
+
+type Position struct {
+	Board int
+	Row   int
+	Col   int
+}
+
+type Win struct {
+	Board int
+	// Index into the calls of the number that completed the board.
+	Turn   int
+	Number int64
+	Score  int64
+	// Which line won, e.g. "row 2", "col 0" or "diagonal".
+	Line string
+}
+
+// Tracks how many cells of each row, column and diagonal are marked, so that
+// each called number is a lookup plus a few counter updates.
+type engine struct {
+	game           *Game
+	countDiagonals bool
+
+	positions map[int64][]Position
+	marked    [][][]bool
+	rowHits   [][]int
+	colHits   [][]int
+	diagHits  [][2]int
+	unmarked  []int64
+	won       []bool
+}
+
+func newEngine(game *Game, countDiagonals bool) *engine {
+	e := &engine{
+		game:           game,
+		countDiagonals: countDiagonals,
+		positions:      make(map[int64][]Position),
+		marked:         make([][][]bool, len(game.Boards)),
+		rowHits:        make([][]int, len(game.Boards)),
+		colHits:        make([][]int, len(game.Boards)),
+		diagHits:       make([][2]int, len(game.Boards)),
+		unmarked:       make([]int64, len(game.Boards)),
+		won:            make([]bool, len(game.Boards)),
+	}
+	for i, b := range game.Boards {
+		e.marked[i] = make([][]bool, b.Size)
+		e.rowHits[i] = make([]int, b.Size)
+		e.colHits[i] = make([]int, b.Size)
+		for row := 0; row < b.Size; row++ {
+			e.marked[i][row] = make([]bool, b.Size)
+			for col := 0; col < b.Size; col++ {
+				n := b.Numbers[row][col]
+				e.positions[n] = append(e.positions[n], Position{Board: i, Row: row, Col: col})
+				e.unmarked[i] += n
+			}
+		}
+	}
+	return e
+}
+
+// Marks the number on every board, and returns the boards that it made win
+// (in board order).
+func (e *engine) call(turn int, n int64) []Win {
+	wins := []Win{}
+	for _, p := range e.positions[n] {
+		if e.won[p.Board] || e.marked[p.Board][p.Row][p.Col] {
+			continue
+		}
+		e.marked[p.Board][p.Row][p.Col] = true
+		e.unmarked[p.Board] -= n
+
+		size := e.game.Boards[p.Board].Size
+		e.rowHits[p.Board][p.Row]++
+		e.colHits[p.Board][p.Col]++
+		line := ""
+		if e.rowHits[p.Board][p.Row] == size {
+			line = fmt.Sprintf("row %d", p.Row)
+		} else if e.colHits[p.Board][p.Col] == size {
+			line = fmt.Sprintf("col %d", p.Col)
+		}
+		if e.countDiagonals {
+			if p.Row == p.Col {
+				e.diagHits[p.Board][0]++
+				if e.diagHits[p.Board][0] == size && line == "" {
+					line = "diagonal"
+				}
+			}
+			if p.Row+p.Col == size-1 {
+				e.diagHits[p.Board][1]++
+				if e.diagHits[p.Board][1] == size && line == "" {
+					line = "anti-diagonal"
+				}
+			}
+		}
+
+		// A board can hold the same number twice, so it only stops taking
+		// marks once this call is over.
+		if line != "" && (len(wins) == 0 || wins[len(wins)-1].Board != p.Board) {
+			wins = append(wins, Win{
+				Board:  p.Board,
+				Turn:   turn,
+				Number: n,
+				Line:   line,
+			})
+		}
+	}
+
+	// positions were indexed board by board, so these are in board order.
+	for i := range wins {
+		e.won[wins[i].Board] = true
+		wins[i].Score = e.unmarked[wins[i].Board] * n
+	}
+	return wins
+}
+
+// Calls every number and returns every board's win, in the order they
+// happened; boards that never win are left out.
+func Play(game *Game, countDiagonals bool) []Win {
+	e := newEngine(game, countDiagonals)
+	wins := []Win{}
+	for turn, n := range game.Calls {
+		wins = append(wins, e.call(turn, n)...)
+		if len(wins) == len(game.Boards) {
+			break
+		}
+	}
+	return wins
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// Writes a random game in the puzzle's format: every number from 0 up to
+// below maxNumber called once in a random order, then the boards, each of
+// them filled with distinct numbers from the same range.
+func generateBingo(w io.Writer, rng *rand.Rand, boards int, size int, maxNumber int) error {
+	if maxNumber < size*size {
+		return fmt.Errorf("need at least %d numbers to fill a %dx%d board, not %d", size*size, size, size, maxNumber)
+	}
+	out := bufio.NewWriter(w)
+	calls := []string{}
+	for _, n := range rng.Perm(maxNumber) {
+		calls = append(calls, fmt.Sprint(n))
+	}
+	fmt.Fprintln(out, strings.Join(calls, ","))
+
+	width := len(fmt.Sprint(maxNumber - 1))
+	for b := 0; b < boards; b++ {
+		fmt.Fprintln(out)
+		numbers := rng.Perm(maxNumber)[:size*size]
+		for row := 0; row < size; row++ {
+			cells := []string{}
+			for _, n := range numbers[row*size : (row+1)*size] {
+				cells = append(cells, fmt.Sprintf("%*d", width, n))
+			}
+			fmt.Fprintln(out, strings.Join(cells, " "))
+		}
+	}
+	return out.Flush()
+}
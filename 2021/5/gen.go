@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Writes random lines in the puzzle's format, all within 0..extent-1 on both
+// axes. diagonalShare of them (0 to 1) are at 45 degrees and the rest are
+// split evenly between horizontal and vertical. No line is a single point.
+func generateVents(w io.Writer, rng *rand.Rand, lines int, extent int64, diagonalShare float64) error {
+	if extent < 2 {
+		return fmt.Errorf("lines need at least 2 points along each axis, not %d", extent)
+	}
+	out := bufio.NewWriter(w)
+	for i := 0; i < lines; i++ {
+		p1 := Point{X: rng.Int63n(extent), Y: rng.Int63n(extent)}
+		p2 := p1
+		for p2 == p1 {
+			switch {
+			case rng.Float64() < diagonalShare:
+				// as far as it can go each way, then a random way along
+				// that
+				dx, dy := int64(1), int64(1)
+				if rng.Intn(2) == 0 {
+					dx = -1
+				}
+				if rng.Intn(2) == 0 {
+					dy = -1
+				}
+				room := extent
+				for _, r := range []int64{roomTowards(p1.X, dx, extent), roomTowards(p1.Y, dy, extent)} {
+					if r < room {
+						room = r
+					}
+				}
+				if room == 0 {
+					continue
+				}
+				n := 1 + rng.Int63n(room)
+				p2 = Point{X: p1.X + dx*n, Y: p1.Y + dy*n}
+			case rng.Intn(2) == 0:
+				p2 = Point{X: rng.Int63n(extent), Y: p1.Y}
+			default:
+				p2 = Point{X: p1.X, Y: rng.Int63n(extent)}
+			}
+		}
+		fmt.Fprintf(out, "%d,%d -> %d,%d\n", p1.X, p1.Y, p2.X, p2.Y)
+	}
+	return out.Flush()
+}
+
+// How many steps there are from v in direction d before leaving 0..extent-1.
+func roomTowards(v, d, extent int64) int64 {
+	if d > 0 {
+		return extent - 1 - v
+	}
+	return v
+}
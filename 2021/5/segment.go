@@ -0,0 +1,330 @@
+package main
+
+import (
+	"sort"
+)
+
+type Point struct {
+	X int64
+	Y int64
+}
+
+func (p Point) Add(o Point) Point   { return Point{X: p.X + o.X, Y: p.Y + o.Y} }
+func (p Point) Sub(o Point) Point   { return Point{X: p.X - o.X, Y: p.Y - o.Y} }
+func (p Point) Scale(k int64) Point { return Point{X: p.X * k, Y: p.Y * k} }
+func (p Point) Dot(o Point) int64   { return p.X*o.X + p.Y*o.Y }
+func (p Point) Cross(o Point) int64 { return p.X*o.Y - p.Y*o.X }
+
+// A segment between two integer points. Only the lattice points on the
+// segment are considered part of it, so any slope works, but horizontal,
+// vertical and 45-degree lines are the ones that cover every step.
+type Line struct {
+	P1      Point
+	P2      Point
+	IsHoriz bool
+	IsVert  bool
+}
+
+func NewLine(p1 Point, p2 Point) Line {
+	//	log.Printf("line from %+v to %+v\n", p1, p2)
+	isHoriz := false
+	isVert := false
+	if p1.Y == p2.Y {
+		isHoriz = true
+		if p2.X < p1.X {
+			p1, p2 = p2, p1
+		}
+	}
+	if p1.X == p2.X {
+		isVert = true
+		if p2.Y < p1.Y {
+			p1, p2 = p2, p1
+		}
+	}
+	if !isHoriz && !isVert {
+		if p2.X < p1.X {
+			p1, p2 = p2, p1
+		}
+	}
+	return Line{
+		P1:      p1,
+		P2:      p2,
+		IsHoriz: isHoriz,
+		IsVert:  isVert,
+	}
+}
+
+func (l Line) IsDiagonal() bool {
+	d := l.P2.Sub(l.P1)
+	return d.X != 0 && (d.X == d.Y || d.X == -d.Y)
+}
+
+func gcd(a, b int64) int64 {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Returns the smallest integer step from P1 towards P2, and how many of those
+// steps it takes to get there. A single-point line steps along +X, so that it
+// groups with horizontal lines.
+func (l Line) Step() (Point, int64) {
+	d := l.P2.Sub(l.P1)
+	g := gcd(d.X, d.Y)
+	if g == 0 {
+		return Point{X: 1}, 0
+	}
+	return Point{X: d.X / g, Y: d.Y / g}, g
+}
+
+// Number of lattice points on the line, including both ends.
+func (l Line) Len() int64 {
+	_, n := l.Step()
+	return n + 1
+}
+
+// Lattice points shared by both lines, computed directly from the endpoints
+// rather than by rasterizing either line.
+func (l Line) Intersect(other Line) []Point {
+	d1, n1 := l.Step()
+	d2, n2 := other.Step()
+	w := other.P1.Sub(l.P1)
+
+	denom := d1.Cross(d2)
+	if denom == 0 {
+		// parallel; nothing in common unless they're on the same line.
+		if w.Cross(d1) != 0 {
+			return nil
+		}
+		// other.P1 is on our line, so it's a whole number of (primitive)
+		// steps from l.P1. Work out the range of steps along our line that
+		// the other line covers.
+		k := w.Dot(d1) / d1.Dot(d1)
+		lo, hi := k, k+n2
+		if d2 != d1 {
+			lo, hi = k-n2, k
+		}
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > n1 {
+			hi = n1
+		}
+		intersections := make([]Point, 0)
+		for t := lo; t <= hi; t++ {
+			intersections = append(intersections, l.P1.Add(d1.Scale(t)))
+		}
+		return intersections
+	}
+
+	// l.P1 + t*d1 == other.P1 + s*d2
+	tNum := w.Cross(d2)
+	sNum := w.Cross(d1)
+	if tNum%denom != 0 || sNum%denom != 0 {
+		// the lines cross between lattice points
+		return nil
+	}
+	t := tNum / denom
+	s := sNum / denom
+	if t < 0 || t > n1 || s < 0 || s > n2 {
+		return nil
+	}
+	return []Point{l.P1.Add(d1.Scale(t))}
+}
+
+// Identifies the infinite line a segment lies on: a canonical primitive
+// direction, and the cross product of any point on the line with it.
+type lineKey struct {
+	dir    Point
+	offset int64
+}
+
+func keyFor(l Line) lineKey {
+	d, _ := l.Step()
+	if d.X < 0 || (d.X == 0 && d.Y < 0) {
+		d = d.Scale(-1)
+	}
+	return lineKey{dir: d, offset: l.P1.Cross(d)}
+}
+
+// Positions along a line are measured as dot products with its direction, so
+// neighbouring lattice points are dir.Dot(dir) apart.
+type span struct {
+	lo int64
+	hi int64
+	// how many of the group's segments cover it
+	count int
+}
+
+type lineGroup struct {
+	key   lineKey
+	lines []Line
+	// every stretch covered by at least one segment, in order
+	spans []span
+}
+
+func (g *lineGroup) pos(p Point) int64 { return p.Dot(g.key.dir) }
+
+func (g *lineGroup) pointAt(pos int64) Point {
+	base := g.lines[0].P1
+	return base.Add(g.key.dir.Scale((pos - g.pos(base)) / g.key.dir.Dot(g.key.dir)))
+}
+
+// Sweeps along the line, recording every stretch covered by the segments and
+// how many cover it. Returns the number of lattice points covered by at least
+// two.
+func (g *lineGroup) sweep() int64 {
+	type event struct {
+		pos   int64
+		delta int
+	}
+	step := g.key.dir.Dot(g.key.dir)
+	events := make([]event, 0, 2*len(g.lines))
+	for _, l := range g.lines {
+		a, b := g.pos(l.P1), g.pos(l.P2)
+		if a > b {
+			a, b = b, a
+		}
+		events = append(events, event{pos: a, delta: 1}, event{pos: b + step, delta: -1})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].pos < events[j].pos })
+
+	total := int64(0)
+	coverage := 0
+	for i := 0; i < len(events); {
+		pos := events[i].pos
+		for ; i < len(events) && events[i].pos == pos; i++ {
+			coverage += events[i].delta
+		}
+		if coverage >= 1 && i < len(events) {
+			next := events[i].pos
+			g.spans = append(g.spans, span{lo: pos, hi: next - step, count: coverage})
+			if coverage >= 2 {
+				total += (next - pos) / step
+			}
+		}
+	}
+	return total
+}
+
+// How many of the group's segments cover p, which must be on the line.
+func (g *lineGroup) coverage(p Point) int {
+	pos := g.pos(p)
+	i := sort.Search(len(g.spans), func(i int) bool { return g.spans[i].hi >= pos })
+	if i < len(g.spans) && g.spans[i].lo <= pos {
+		return g.spans[i].count
+	}
+	return 0
+}
+
+// Sorts the lines into groups, one per infinite line, and sweeps each group.
+func sweepGroups(lines []Line) []*lineGroup {
+	groupIdx := make(map[lineKey]int)
+	groups := make([]*lineGroup, 0)
+	for _, l := range lines {
+		key := keyFor(l)
+		i, exists := groupIdx[key]
+		if !exists {
+			i = len(groups)
+			groupIdx[key] = i
+			groups = append(groups, &lineGroup{key: key})
+		}
+		groups[i].lines = append(groups[i].lines, l)
+	}
+	for _, g := range groups {
+		g.sweep()
+	}
+	return groups
+}
+
+// Single-point crossings between lines in different groups, with the groups
+// whose lines pass through each. Every line is tried against every line in
+// every other group.
+func crossings(groups []*lineGroup) map[Point]map[int]struct{} {
+	through := make(map[Point]map[int]struct{})
+	for gi := 0; gi < len(groups); gi++ {
+		for gj := gi + 1; gj < len(groups); gj++ {
+			if groups[gi].key.dir == groups[gj].key.dir {
+				continue
+			}
+			for _, a := range groups[gi].lines {
+				for _, b := range groups[gj].lines {
+					for _, p := range a.Intersect(b) {
+						if _, exists := through[p]; !exists {
+							through[p] = make(map[int]struct{})
+						}
+						through[p][gi] = struct{}{}
+						through[p][gj] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+	return through
+}
+
+// Number of lattice points covered by at least two of the lines. Segments on
+// a common line are swept as intervals, and crossings between different lines
+// are found analytically, so the cost doesn't depend on how long the lines
+// are. It does grow with the square of how many there are, though, since
+// every pair of lines in different directions is checked for a crossing.
+func CountOverlaps(lines []Line) int64 {
+	groups := sweepGroups(lines)
+	total := int64(0)
+	for _, g := range groups {
+		for _, s := range g.spans {
+			if s.count >= 2 {
+				total += (s.hi-s.lo)/g.key.dir.Dot(g.key.dir) + 1
+			}
+		}
+	}
+
+	// A crossing can also sit inside the overlap stretches of several groups,
+	// in which case the spans above have already counted it once per group.
+	for p, through := range crossings(groups) {
+		timesCounted := int64(0)
+		for gi := range through {
+			if groups[gi].coverage(p) >= 2 {
+				timesCounted++
+			}
+		}
+		total += 1 - timesCounted
+	}
+
+	return total
+}
+
+// How many lines cover each lattice point covered by at least two, from the
+// same sweeps and crossings as CountOverlaps: only the overlapping points are
+// visited, not every point of every line.
+func OverlapCounts(lines []Line) map[Point]int {
+	groups := sweepGroups(lines)
+	counts := make(map[Point]int)
+	for _, g := range groups {
+		step := g.key.dir.Dot(g.key.dir)
+		for _, s := range g.spans {
+			if s.count < 2 {
+				continue
+			}
+			for pos := s.lo; pos <= s.hi; pos += step {
+				counts[g.pointAt(pos)] = s.count
+			}
+		}
+	}
+	// a crossing is covered by every line through it, from each group
+	for p, through := range crossings(groups) {
+		count := 0
+		for gi := range through {
+			count += groups[gi].coverage(p)
+		}
+		counts[p] = count
+	}
+	return counts
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func pt(x, y int64) Point { return Point{X: x, Y: y} }
+
+func sortPoints(points []Point) []Point {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+		return points[i].X < points[j].X
+	})
+	return points
+}
+
+// Every lattice point on the line, one step at a time.
+func rasterize(l Line) []Point {
+	step, n := l.Step()
+	points := []Point{}
+	for i := int64(0); i <= n; i++ {
+		points = append(points, l.P1.Add(step.Scale(i)))
+	}
+	return points
+}
+
+func TestIntersect(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b Line
+		want []Point
+	}{
+		{"collinear partial overlap", NewLine(pt(0, 0), pt(5, 0)), NewLine(pt(3, 0), pt(8, 0)),
+			[]Point{pt(3, 0), pt(4, 0), pt(5, 0)}},
+		{"collinear, touching at the ends", NewLine(pt(0, 0), pt(2, 0)), NewLine(pt(2, 0), pt(4, 0)),
+			[]Point{pt(2, 0)}},
+		{"collinear with a gap", NewLine(pt(0, 0), pt(2, 2)), NewLine(pt(4, 4), pt(6, 6)),
+			nil},
+		{"one inside the other", NewLine(pt(1, 0), pt(1, 9)), NewLine(pt(1, 3), pt(1, 4)),
+			[]Point{pt(1, 3), pt(1, 4)}},
+		{"opposite directions, diagonal", Line{P1: pt(0, 0), P2: pt(4, 4)}, Line{P1: pt(6, 6), P2: pt(2, 2)},
+			[]Point{pt(2, 2), pt(3, 3), pt(4, 4)}},
+		{"opposite directions, other slope", Line{P1: pt(0, 0), P2: pt(6, 3)}, Line{P1: pt(8, 4), P2: pt(2, 1)},
+			[]Point{pt(2, 1), pt(4, 2), pt(6, 3)}},
+		{"parallel, apart", NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(0, 1), pt(4, 1)),
+			nil},
+		{"single point on a line", NewLine(pt(2, 2), pt(2, 2)), NewLine(pt(0, 2), pt(4, 2)),
+			[]Point{pt(2, 2)}},
+		{"single point on a diagonal", NewLine(pt(3, 3), pt(3, 3)), NewLine(pt(0, 0), pt(4, 4)),
+			[]Point{pt(3, 3)}},
+		{"single point off a line", NewLine(pt(2, 3), pt(2, 3)), NewLine(pt(0, 2), pt(4, 2)),
+			nil},
+		{"single points, same", NewLine(pt(5, 5), pt(5, 5)), NewLine(pt(5, 5), pt(5, 5)),
+			[]Point{pt(5, 5)}},
+		{"single points, different", NewLine(pt(5, 5), pt(5, 5)), NewLine(pt(5, 6), pt(5, 6)),
+			nil},
+		{"crossing on a lattice point", NewLine(pt(0, 0), pt(4, 4)), NewLine(pt(0, 4), pt(4, 0)),
+			[]Point{pt(2, 2)}},
+		{"crossing between lattice points", NewLine(pt(0, 0), pt(1, 1)), NewLine(pt(0, 1), pt(1, 0)),
+			nil},
+		{"crossing between another slope's points", NewLine(pt(0, 0), pt(3, 1)), NewLine(pt(0, 1), pt(3, 0)),
+			nil},
+		{"steep line crossing a vertical", NewLine(pt(0, 0), pt(2, 4)), NewLine(pt(1, 0), pt(1, 4)),
+			[]Point{pt(1, 2)}},
+		{"would cross past an end", NewLine(pt(0, 0), pt(2, 0)), NewLine(pt(3, -1), pt(3, 1)),
+			nil},
+	} {
+		for _, order := range [][2]Line{{tc.a, tc.b}, {tc.b, tc.a}} {
+			got := sortPoints(order[0].Intersect(order[1]))
+			if len(got) == 0 && len(tc.want) == 0 {
+				continue
+			}
+			if want := sortPoints(append([]Point{}, tc.want...)); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %v.Intersect(%v) = %v, want %v", tc.name, order[0], order[1], got, want)
+			}
+		}
+	}
+}
+
+func TestCountOverlaps(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		lines []Line
+		want  int64
+	}{
+		{"none", []Line{NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(0, 1), pt(4, 1))}, 0},
+		{"collinear partial overlap", []Line{NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(2, 0), pt(6, 0))}, 3},
+		{"three deep", []Line{NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(2, 0), pt(6, 0)), NewLine(pt(3, 0), pt(3, 0))}, 3},
+		{"opposite directions", []Line{{P1: pt(0, 0), P2: pt(4, 4)}, {P1: pt(6, 6), P2: pt(2, 2)}}, 3},
+		{"crossing inside a span", []Line{
+			NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(2, 0), pt(6, 0)),
+			NewLine(pt(3, -2), pt(3, 2)),
+		}, 3},
+		{"crossing inside spans in both directions", []Line{
+			NewLine(pt(0, 0), pt(4, 0)), NewLine(pt(2, 0), pt(6, 0)),
+			NewLine(pt(3, -2), pt(3, 2)), NewLine(pt(3, -1), pt(3, 3)),
+		}, 6},
+		{"three lines through one point", []Line{
+			NewLine(pt(0, 0), pt(4, 4)), NewLine(pt(0, 4), pt(4, 0)), NewLine(pt(2, 0), pt(2, 4)),
+		}, 1},
+		{"crossing between lattice points", []Line{NewLine(pt(0, 0), pt(1, 1)), NewLine(pt(0, 1), pt(1, 0))}, 0},
+		{"puzzle example", []Line{
+			NewLine(pt(0, 9), pt(5, 9)), NewLine(pt(8, 0), pt(0, 8)), NewLine(pt(9, 4), pt(3, 4)),
+			NewLine(pt(2, 2), pt(2, 1)), NewLine(pt(7, 0), pt(7, 4)), NewLine(pt(6, 4), pt(2, 0)),
+			NewLine(pt(0, 9), pt(2, 9)), NewLine(pt(3, 4), pt(1, 4)), NewLine(pt(0, 0), pt(8, 8)),
+			NewLine(pt(5, 5), pt(8, 2)),
+		}, 12},
+	} {
+		if got := CountOverlaps(tc.lines); got != tc.want {
+			t.Errorf("%s: CountOverlaps = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+// A random line: horizontal, vertical, diagonal, a single point, or any
+// slope at all.
+func randomLine(rng *rand.Rand, extent int64) Line {
+	p1 := pt(rng.Int63n(extent), rng.Int63n(extent))
+	p2 := pt(rng.Int63n(extent), rng.Int63n(extent))
+	switch rng.Intn(5) {
+	case 0:
+		p2.Y = p1.Y
+	case 1:
+		p2.X = p1.X
+	case 2:
+		d := p2.X - p1.X
+		if rng.Intn(2) == 0 {
+			d = -d
+		}
+		p2.Y = p1.Y + d
+	case 3:
+		p2 = p1
+	}
+	if rng.Intn(2) == 0 {
+		// the other way round from how NewLine puts it
+		l := NewLine(p1, p2)
+		return Line{P1: l.P2, P2: l.P1, IsHoriz: l.IsHoriz, IsVert: l.IsVert}
+	}
+	return NewLine(p1, p2)
+}
+
+func TestOverlapsMatchRasterizing(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		lines := make([]Line, 1+rng.Intn(12))
+		for j := range lines {
+			lines[j] = randomLine(rng, 10)
+		}
+
+		counts := make(map[Point]int)
+		for _, l := range lines {
+			for _, p := range rasterize(l) {
+				counts[p]++
+			}
+		}
+		want := make(map[Point]int)
+		for p, c := range counts {
+			if c >= 2 {
+				want[p] = c
+			}
+		}
+
+		if got := CountOverlaps(lines); got != int64(len(want)) {
+			t.Fatalf("CountOverlaps(%v) = %d, want %d", lines, got, len(want))
+		}
+		if got := OverlapCounts(lines); !reflect.DeepEqual(got, want) {
+			t.Fatalf("OverlapCounts(%v) = %v, want %v", lines, got, want)
+		}
+
+		a, b := lines[0], lines[len(lines)-1]
+		onA := make(map[Point]bool)
+		for _, p := range rasterize(a) {
+			onA[p] = true
+		}
+		shared := []Point{}
+		for _, p := range rasterize(b) {
+			if onA[p] {
+				shared = append(shared, p)
+			}
+		}
+		if got := sortPoints(a.Intersect(b)); len(got)+len(shared) > 0 && !reflect.DeepEqual(got, sortPoints(shared)) {
+			t.Fatalf("%v.Intersect(%v) = %v, want %v", a, b, got, shared)
+		}
+	}
+}
@@ -2,12 +2,13 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"math/rand"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 )
 
@@ -26,34 +27,44 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
-func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
+var (
+	svgPath   = flag.String("svg", "", "also draw the lines into this .svg file")
+	heatmap   = flag.Bool("heatmap", true, "with -svg, shade points by how many lines cover them")
+	highlight = flag.Bool("highlight", false, "with -svg, color horizontal, vertical and diagonal lines differently")
+	generate  = flag.Bool("generate", false, "instead of solving, print random lines to use as input")
+	seed      = flag.Int64("seed", 1, "random seed for -generate")
+	numLines  = flag.Int("lines", 500, "how many lines -generate makes")
+	extent    = flag.Int64("extent", 1000, "-generate keeps lines between 0 and this on both axes")
+	diagonals = flag.Float64("diagonals", 0.3, "share of generated lines that are diagonal")
+)
 
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+func main() {
+	flag.Parse()
+	if *generate {
+		if err := generateVents(os.Stdout, rand.New(rand.NewSource(*seed)), *numLines, *extent, *diagonals); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := RunSolver[[]Line](ventMap{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var (
@@ -95,90 +106,19 @@ func extractRegexp(pattern *regexp.Regexp, str string) (*parsedParams, error) {
 	return params, nil
 }
 
-type Point struct {
-	X int64
-	Y int64
-}
+type ventMap struct{}
 
-type Line struct {
-	P1      Point
-	P2      Point
-	IsHoriz bool
-	IsVert  bool
-}
-
-func NewLine(p1 Point, p2 Point) Line {
-	//	log.Printf("line from %+v to %+v\n", p1, p2)
-	isHoriz := false
-	isVert := false
-	if p1.Y == p2.Y {
-		isHoriz = true
-		if p2.X < p1.X {
-			p1, p2 = p2, p1
-		}
-	}
-	if p1.X == p2.X {
-		isVert = true
-		if p2.Y < p1.Y {
-			p1, p2 = p2, p1
-		}
-	}
-	if !isHoriz && !isVert {
-		if p2.X < p1.X {
-			p1, p2 = p2, p1
-		}
-	}
-	return Line{
-		P1:      p1,
-		P2:      p2,
-		IsHoriz: isHoriz,
-		IsVert:  isVert,
-	}
-}
-
-func (l Line) Points() map[Point]struct{} {
-	points := make(map[Point]struct{})
-	p := l.P1
-	for {
-		points[p] = struct{}{}
-		if p == l.P2 {
-			break
-		}
-		if l.P2.X > p.X {
-			p.X++
-		}
-		if l.P2.Y > p.Y {
-			p.Y++
-		}
-		if l.P2.Y < p.Y {
-			p.Y--
-		}
-	}
-	return points
-}
-
-func (l Line) Intersect(other Line) []Point {
-	l1Points := l.Points()
-	l2Points := other.Points()
-
-	intersections := make([]Point, 0)
-	for p := range l1Points {
-		if _, in := l2Points[p]; in {
-			intersections = append(intersections, p)
-		}
-	}
-	return intersections
-}
+func (ventMap) Parse(input io.Reader) ([]Line, error) {
+	scanner := newScanner(input)
 
-func part1(input *scanner) int {
 	// Setup
-	lineNo := 0
+	lineNo := 1
 	lines := make([]Line, 0)
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
 		// Parse line into components
 		parsedLine, err := extractRegexp(inputFormat, line)
 		if err != nil {
-			log.Fatalf("input line %d: %v", lineNo, err)
+			return nil, fmt.Errorf("input line %d: %w", lineNo, err)
 		}
 
 		// Process line
@@ -194,68 +134,42 @@ func part1(input *scanner) int {
 		))
 		lineNo++
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	if err := scanner.Finish(); err != nil {
+		return nil, err
 	}
+	return lines, nil
+}
 
-	//	log.Printf("%v", lines)
-
-	collisions := make(map[Point]int)
-	for i := 0; i < len(lines); i++ {
-		if !lines[i].IsHoriz && !lines[i].IsVert {
-			continue
-		}
-		for j := i + 1; j < len(lines); j++ {
-			if !lines[j].IsHoriz && !lines[j].IsVert {
-				continue
-			}
-			for _, p := range lines[i].Intersect(lines[j]) {
-				collisions[p]++
-			}
+func (ventMap) Part1(lines []Line) (Answer, error) {
+	straightLines := make([]Line, 0)
+	for _, l := range lines {
+		if l.IsHoriz || l.IsVert {
+			straightLines = append(straightLines, l)
 		}
 	}
-
-	return len(collisions)
+	return CountOverlaps(straightLines), nil
 }
 
-func part2(input *scanner) int {
-	// Setup
-	lineNo := 0
-	lines := make([]Line, 0)
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		parsedLine, err := extractRegexp(inputFormat, line)
-		if err != nil {
-			log.Fatalf("input line %d: %v", lineNo, err)
-		}
+func (ventMap) Part2(lines []Line) (Answer, error) {
+	return CountOverlaps(lines), nil
+}
 
-		// Process line
-		lines = append(lines, NewLine(
-			Point{
-				X: parsedLine.Numbers["x1"],
-				Y: parsedLine.Numbers["y1"],
-			},
-			Point{
-				X: parsedLine.Numbers["x2"],
-				Y: parsedLine.Numbers["y2"],
-			},
-		))
-		lineNo++
+// With -svg, draws the lines before solving.
+func (ventMap) BeforeSolving(lines []Line) (bool, error) {
+	if *svgPath == "" {
+		return true, nil
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
-	}
-
-	//	log.Printf("%v", lines)
+	return true, exportSVG(lines, *svgPath)
+}
 
-	collisions := make(map[Point]int)
-	for i := 0; i < len(lines); i++ {
-		for j := i + 1; j < len(lines); j++ {
-			for _, p := range lines[i].Intersect(lines[j]) {
-				collisions[p]++
-			}
-		}
+func exportSVG(lines []Line, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
 	}
-
-	return len(collisions)
+	if err := WriteSVG(f, lines, SVGOptions{Heatmap: *heatmap, HighlightKinds: *highlight}); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
 }
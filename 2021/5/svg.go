@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+)
+
+type SVGOptions struct {
+	// Shade every point covered by two or more lines, hotter the more lines
+	// cover it.
+	Heatmap bool
+	// Color horizontal, vertical, diagonal and any other lines differently,
+	// instead of all the same.
+	HighlightKinds bool
+}
+
+const (
+	plainStroke = "#555555"
+	horizStroke = "#1f77b4"
+	vertStroke  = "#2ca02c"
+	diagStroke  = "#ff7f0e"
+	otherStroke = "#9467bd"
+)
+
+func (l Line) stroke(highlight bool) string {
+	switch {
+	case !highlight:
+		return plainStroke
+	case l.IsHoriz:
+		return horizStroke
+	case l.IsVert:
+		return vertStroke
+	case l.IsDiagonal():
+		return diagStroke
+	default:
+		return otherStroke
+	}
+}
+
+// Shades from pale yellow at the fewest overlaps to dark red at the most.
+func heatColor(count, lo, hi int) string {
+	t := 1.0
+	if hi > lo {
+		t = float64(count-lo) / float64(hi-lo)
+	}
+	mix := func(a, b float64) int { return int(a + t*(b-a) + 0.5) }
+	return fmt.Sprintf("#%02x%02x%02x", mix(255, 160), mix(230, 0), mix(120, 20))
+}
+
+// Draws the lines as an SVG, one unit per lattice point, with y increasing
+// downwards as in the puzzle. Each line has a dot on its P1 end, so it's easy
+// to see which way round NewLine put it.
+func WriteSVG(w io.Writer, lines []Line, opts SVGOptions) error {
+	out := bufio.NewWriter(w)
+
+	minX, minY, maxX, maxY := int64(0), int64(0), int64(0), int64(0)
+	for i, l := range lines {
+		for _, p := range []Point{l.P1, l.P2} {
+			if i == 0 || p.X < minX {
+				minX = p.X
+			}
+			if i == 0 || p.Y < minY {
+				minY = p.Y
+			}
+			if i == 0 || p.X > maxX {
+				maxX = p.X
+			}
+			if i == 0 || p.Y > maxY {
+				maxY = p.Y
+			}
+		}
+	}
+	fmt.Fprintf(out, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"%d %d %d %d\">\n", minX-1, minY-1, maxX-minX+2, maxY-minY+2)
+	fmt.Fprintf(out, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"white\"/>\n", minX-1, minY-1, maxX-minX+2, maxY-minY+2)
+
+	if opts.Heatmap {
+		counts := OverlapCounts(lines)
+		overlaps := make([]Point, 0, len(counts))
+		most := 0
+		for p, c := range counts {
+			overlaps = append(overlaps, p)
+			if c > most {
+				most = c
+			}
+		}
+		// map order would make the file different every time
+		sort.Slice(overlaps, func(i, j int) bool {
+			if overlaps[i].Y != overlaps[j].Y {
+				return overlaps[i].Y < overlaps[j].Y
+			}
+			return overlaps[i].X < overlaps[j].X
+		})
+		out.WriteString("<g id=\"overlaps\">\n")
+		for _, p := range overlaps {
+			fmt.Fprintf(out, "<rect x=\"%g\" y=\"%g\" width=\"1\" height=\"1\" fill=\"%s\"><title>%d,%d: %d lines</title></rect>\n",
+				float64(p.X)-0.5, float64(p.Y)-0.5, heatColor(counts[p], 2, most), p.X, p.Y, counts[p])
+		}
+		out.WriteString("</g>\n")
+	}
+
+	out.WriteString("<g id=\"lines\" stroke-width=\"0.3\" stroke-linecap=\"round\" stroke-opacity=\"0.7\">\n")
+	for _, l := range lines {
+		stroke := l.stroke(opts.HighlightKinds)
+		fmt.Fprintf(out, "<line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"%s\"><title>%d,%d -&gt; %d,%d</title></line>\n",
+			l.P1.X, l.P1.Y, l.P2.X, l.P2.Y, stroke, l.P1.X, l.P1.Y, l.P2.X, l.P2.Y)
+		fmt.Fprintf(out, "<circle cx=\"%d\" cy=\"%d\" r=\"0.4\" fill=\"%s\"/>\n", l.P1.X, l.P1.Y, stroke)
+	}
+	out.WriteString("</g>\n</svg>\n")
+
+	return out.Flush()
+}
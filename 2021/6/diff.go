@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// A school of fish and how long to watch them for.
+type fishCase struct {
+	Fish []int
+	Days int
+}
+
+func (c fishCase) disagrees() (int, int, bool) {
+	simulated, counted := simulateFish(c.Fish, c.Days), countFish(c.Fish, c.Days)
+	return simulated, counted, simulated != counted
+}
+
+func (c fishCase) fails() bool {
+	_, _, bad := c.disagrees()
+	return bad
+}
+
+// Simpler schools to try when one disagrees: with a fish fewer, with a fish
+// nearer to spawning, or watched for a day less.
+func (c fishCase) shrinks() []fishCase {
+	shrinks := []fishCase{}
+	if c.Days > 0 {
+		shrinks = append(shrinks, fishCase{Fish: c.Fish, Days: c.Days - 1})
+	}
+	for i := range c.Fish {
+		fewer := append(append([]int{}, c.Fish[:i]...), c.Fish[i+1:]...)
+		shrinks = append(shrinks, fishCase{Fish: fewer, Days: c.Days})
+		if c.Fish[i] > 0 {
+			younger := append([]int{}, c.Fish...)
+			younger[i]--
+			shrinks = append(shrinks, fishCase{Fish: younger, Days: c.Days})
+		}
+	}
+	return shrinks
+}
+
+// Runs both ways of counting fish on random schools (every timer from 0 to
+// 8, not just the 1 to 5 real inputs start with) for every number of days up
+// to maxDays, and reports the simplest disagreement it can find, if any.
+func differential(rng *rand.Rand, runs int, maxDays int) error {
+	for run := 0; run < runs; run++ {
+		fish := make([]int, rng.Intn(10))
+		for i := range fish {
+			fish[i] = rng.Intn(9)
+		}
+		for days := 0; days <= maxDays; days++ {
+			c := fishCase{Fish: fish, Days: days}
+			if c.fails() {
+				c = minimize(c, fishCase.shrinks, fishCase.fails)
+				simulated, counted, _ := c.disagrees()
+				return fmt.Errorf("run %d: after %d days, fish %v become %d simulated but %d counted", run+1, c.Days, c.Fish, simulated, counted)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFishCounts(t *testing.T) {
+	example := []int{3, 4, 3, 1, 2}
+	for _, tc := range []struct {
+		fish []int
+		days int
+		want int
+	}{
+		{example, 0, 5},
+		{example, 18, 26},
+		{example, 80, 5934},
+		{[]int{0}, 1, 2},
+		{[]int{8}, 8, 1},
+		{[]int{8}, 9, 2},
+		{nil, 10, 0},
+	} {
+		if got := simulateFish(tc.fish, tc.days); got != tc.want {
+			t.Errorf("simulateFish(%v, %d) = %d, want %d", tc.fish, tc.days, got, tc.want)
+		}
+		if got := countFish(tc.fish, tc.days); got != tc.want {
+			t.Errorf("countFish(%v, %d) = %d, want %d", tc.fish, tc.days, got, tc.want)
+		}
+	}
+}
+
+func TestSimulatingAgreesWithCounting(t *testing.T) {
+	if err := differential(rand.New(rand.NewSource(1)), 200, 40); err != nil {
+		t.Error(err)
+	}
+}
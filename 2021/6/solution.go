@@ -1,26 +1,67 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var (
+	diffRuns = flag.Int("diff", 0, "instead of solving, check the simulation and the counting agree on this many random schools of fish")
+	diffSeed = flag.Int64("seed", 1, "random seed for -diff")
+	diffDays = flag.Int("days", 40, "-diff tries every number of days up to this")
 )
 
 func main() {
-	fmt.Printf("Part 1 solution: %d\n", part1())
+	flag.Parse()
+	if *diffRuns > 0 {
+		if err := differential(rand.New(rand.NewSource(*diffSeed)), *diffRuns, *diffDays); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("simulating and counting agreed on %d random schools\n", *diffRuns)
+		return
+	}
 
-	fmt.Printf("Part 2 solution: %d\n", part2())
+	if err := RunSolver[[]int](lanternfish{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
-var (
-	input = []int{
-		1, 1, 3, 5, 3, 1, 1, 4, 1, 1, 5, 2, 4, 3, 1, 1, 3, 1, 1, 5, 5, 1, 3, 2, 5, 4, 1, 1, 5, 1, 4, 2, 1, 4, 2, 1, 4, 4, 1, 5, 1, 4, 4, 1, 1, 5, 1, 5, 1, 5, 1, 1, 1, 5, 1, 2, 5, 1, 1, 3, 2, 2, 2, 1, 4, 1, 1, 2, 4, 1, 3, 1, 2, 1, 3, 5, 2, 3, 5, 1, 1, 4, 3, 3, 5, 1, 5, 3, 1, 2, 3, 4, 1, 1, 5, 4, 1, 3, 4, 4, 1, 2, 4, 4, 1, 1, 3, 5, 3, 1, 2, 2, 5, 1, 4, 1, 3, 3, 3, 3, 1, 1, 2, 1, 5, 3, 4, 5, 1, 5, 2, 5, 3, 2, 1, 4, 2, 1, 1, 1, 4, 1, 2, 1, 2, 2, 4, 5, 5, 5, 4, 1, 4, 1, 4, 2, 3, 2, 3, 1, 1, 2, 3, 1, 1, 1, 5, 2, 2, 5, 3, 1, 4, 1, 2, 1, 1, 5, 3, 1, 4, 5, 1, 4, 2, 1, 1, 5, 1, 5, 4, 1, 5, 5, 2, 3, 1, 3, 5, 1, 1, 1, 1, 3, 1, 1, 4, 1, 5, 2, 1, 1, 3, 5, 1, 1, 4, 2, 1, 2, 5, 2, 5, 1, 1, 1, 2, 3, 5, 5, 1, 4, 3, 2, 2, 3, 2, 1, 1, 4, 1, 3, 5, 2, 3, 1, 1, 5, 1, 3, 5, 1, 1, 5, 5, 3, 1, 3, 3, 1, 2, 3, 1, 5, 1, 3, 2, 1, 3, 1, 1, 2, 3, 5, 3, 5, 5, 4, 3, 1, 5, 1, 1, 2, 3, 2, 2, 1, 1, 2, 1, 4, 1, 2, 3, 3, 3, 1, 3, 5,
+type lanternfish struct{}
+
+// Reads the fish's timers, all on one line separated by commas.
+func (lanternfish) Parse(input io.Reader) ([]int, error) {
+	contents, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
 	}
-)
+	fish := []int{}
+	for i, timer := range strings.Split(strings.TrimSpace(string(contents)), ",") {
+		t, err := strconv.Atoi(timer)
+		if err != nil {
+			return nil, fmt.Errorf("fish %d: %w", i+1, err)
+		}
+		if t < 0 || t > 8 {
+			return nil, fmt.Errorf("fish %d has timer %d; it should be 0 to 8", i+1, t)
+		}
+		fish = append(fish, t)
+	}
+	return fish, nil
+}
 
-func part1() int {
-	state := make([]int, len(input))
-	copy(state, input)
+func (lanternfish) Part1(fish []int) (Answer, error) {
+	return simulateFish(fish, 80), nil
+}
 
-	for day := 0; day < 80; day++ {
+// Follows every fish, one by one, so the work doubles every week or so.
+func simulateFish(fish []int, days int) int {
+	state := make([]int, len(fish))
+	copy(state, fish)
+	for day := 0; day < days; day++ {
 		startingFish := len(state)
 		// log.Printf("starting day %d with %d fish", day, startingFish)
 		for fish := 0; fish < startingFish; fish++ {
@@ -36,12 +77,19 @@ func part1() int {
 	return len(state)
 }
 
-func part2() int {
+func (lanternfish) Part2(fish []int) (Answer, error) {
+	return countFish(fish, 256), nil
+}
+
+// Only keeps how many fish there are with each timer, so each day is the same
+// small amount of work however many fish there are.
+func countFish(fish []int, days int) int {
 	states := make(map[int]int)
-	for _, fishState := range input {
+	for _, fishState := range fish {
 		states[fishState]++
 	}
-	for day := 0; day < 256; day++ {
+
+	for day := 0; day < days; day++ {
 		newState := make(map[int]int)
 		splits := 0
 		for state, count := range states {
@@ -54,7 +102,6 @@ func part2() int {
 		newState[6] += splits
 		newState[8] += splits
 		states = newState
-
 		// 		fmt.Printf("After day %d: %v\n", day, states)
 	}
 
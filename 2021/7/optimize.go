@@ -0,0 +1,199 @@
+package main
+
+import (
+	"sort"
+)
+
+// What it costs to move one crab some distance. Of must be convex and never
+// decreasing in the distance, so that the total cost has no local minima
+// other than the global one.
+type Cost struct {
+	Name string
+	Of   func(distance int64) int64
+
+	// If set, a few positions that must include an optimum, worked out from
+	// the crabs' sorted positions without trying every center.
+	candidates func(sorted []int64) []int64
+
+	// If set, Of is the polynomial sum(coeffs[k] * d^k) / divisor, so the
+	// total for a center comes from prefix sums of the sorted positions'
+	// powers instead of a pass over every crab.
+	coeffs  []int64
+	divisor int64
+}
+
+var (
+	// Each step costs 1; the median is optimal.
+	LinearCost = Cost{
+		Name: "linear",
+		Of:   func(d int64) int64 { return d },
+		candidates: func(sorted []int64) []int64 {
+			return []int64{sorted[len(sorted)/2]}
+		},
+		coeffs:  []int64{0, 1},
+		divisor: 1,
+	}
+
+	// The nth step costs n; the optimum is within half a step of the mean
+	// (the cost is d^2/2 plus a d/2 term that can pull it that far), so it's
+	// one of the integers around it.
+	TriangularCost = Cost{
+		Name: "triangular",
+		Of:   func(d int64) int64 { return d * (d + 1) / 2 },
+		candidates: func(sorted []int64) []int64 {
+			sum := int64(0)
+			for _, p := range sorted {
+				sum += p
+			}
+			n := int64(len(sorted))
+			mean := sum / n
+			if sum%n != 0 && sum < 0 {
+				mean-- // round towards -inf
+			}
+			return []int64{mean - 1, mean, mean + 1, mean + 2}
+		},
+		coeffs:  []int64{0, 1, 1},
+		divisor: 2,
+	}
+)
+
+// Any other convex cost; the optimum is found by ternary search, totalling
+// every crab for each center tried.
+func CustomCost(name string, of func(distance int64) int64) Cost {
+	return Cost{Name: name, Of: of}
+}
+
+// A cost of coeffs[0] + coeffs[1]*d + coeffs[2]*d^2 + ...; the coefficients
+// must not be negative, so that it's convex. The optimum is found by ternary
+// search like CustomCost, but each center's total takes a binary search over
+// the sorted positions rather than a pass over them all.
+func PolynomialCost(name string, coeffs ...int64) Cost {
+	return Cost{
+		Name: name,
+		Of: func(d int64) int64 {
+			total := int64(0)
+			for k := len(coeffs) - 1; k >= 0; k-- {
+				total = total*d + coeffs[k]
+			}
+			return total
+		},
+		coeffs:  coeffs,
+		divisor: 1,
+	}
+}
+
+// Crab positions in order, with prefix sums of their powers: powers[j][i] is
+// the sum of sorted[:i] each raised to the jth power.
+type prefixSums struct {
+	sorted []int64
+	powers [][]int64
+}
+
+func newPrefixSums(sorted []int64, degree int) *prefixSums {
+	s := &prefixSums{sorted: sorted, powers: make([][]int64, degree+1)}
+	for j := range s.powers {
+		s.powers[j] = make([]int64, len(sorted)+1)
+		for i, p := range sorted {
+			s.powers[j][i+1] = s.powers[j][i] + pow(p, j)
+		}
+	}
+	return s
+}
+
+// The sum over every crab of |p - center|^k, expanding (center - p)^k for the
+// crabs before center and (p - center)^k for the rest binomially.
+func (s *prefixSums) distancePowerSum(center int64, k int) int64 {
+	split := sort.Search(len(s.sorted), func(i int) bool { return s.sorted[i] >= center })
+	n := len(s.sorted)
+	total := int64(0)
+	binomial := int64(1)
+	for j := 0; j <= k; j++ {
+		before := s.powers[j][split]
+		after := s.powers[j][n] - before
+		c := binomial * pow(center, k-j)
+		total += c * pow(-1, j) * before
+		total += c * pow(-1, k-j) * after
+		binomial = binomial * int64(k-j) / int64(j+1)
+	}
+	return total
+}
+
+func (s *prefixSums) total(cost Cost, center int64) int64 {
+	total := int64(0)
+	for k, coeff := range cost.coeffs {
+		if coeff != 0 {
+			total += coeff * s.distancePowerSum(center, k)
+		}
+	}
+	return total / cost.divisor
+}
+
+func pow(base int64, exp int) int64 {
+	result := int64(1)
+	for ; exp > 0; exp-- {
+		result *= base
+	}
+	return result
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func totalCost(positions []int64, cost Cost, center int64) int64 {
+	total := int64(0)
+	for _, p := range positions {
+		total += cost.Of(abs(p - center))
+	}
+	return total
+}
+
+// Finds the position that costs least for every crab to move to, and that
+// cost. If several positions tie, any of them may be returned.
+func Optimize(positions []int64, cost Cost) (int64, int64) {
+	if len(positions) == 0 {
+		return 0, 0
+	}
+	sorted := make([]int64, len(positions))
+	copy(sorted, positions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	totalAt := func(center int64) int64 { return totalCost(sorted, cost, center) }
+	if cost.coeffs != nil {
+		sums := newPrefixSums(sorted, len(cost.coeffs)-1)
+		totalAt = func(center int64) int64 { return sums.total(cost, center) }
+	}
+
+	if cost.candidates != nil {
+		best, bestCost := int64(0), int64(-1)
+		for _, c := range cost.candidates(sorted) {
+			if thisCost := totalAt(c); bestCost == -1 || thisCost < bestCost {
+				best, bestCost = c, thisCost
+			}
+		}
+		return best, bestCost
+	}
+
+	// The total is convex in the center too, so narrow [lo, hi] by thirds,
+	// dropping whichever end is costlier.
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	for hi-lo > 2 {
+		m1 := lo + (hi-lo)/3
+		m2 := hi - (hi-lo)/3
+		if totalAt(m1) <= totalAt(m2) {
+			hi = m2
+		} else {
+			lo = m1
+		}
+	}
+	best, bestCost := lo, totalAt(lo)
+	for c := lo + 1; c <= hi; c++ {
+		if thisCost := totalAt(c); thisCost < bestCost {
+			best, bestCost = c, thisCost
+		}
+	}
+	return best, bestCost
+}
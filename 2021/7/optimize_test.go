@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var testCosts = []Cost{
+	LinearCost,
+	TriangularCost,
+	CustomCost("squared", func(d int64) int64 { return d * d }),
+	CustomCost("free for 3 steps", func(d int64) int64 {
+		if d < 3 {
+			return 0
+		}
+		return d - 3
+	}),
+	PolynomialCost("cubic", 5, 0, 2, 1),
+}
+
+// The cheapest total found by trying every center between the outermost crabs.
+func bruteForce(positions []int64, cost Cost) int64 {
+	lo, hi := positions[0], positions[0]
+	for _, p := range positions {
+		lo, hi = min(lo, p), max(hi, p)
+	}
+	best := totalCost(positions, cost, lo)
+	for c := lo + 1; c <= hi; c++ {
+		best = min(best, totalCost(positions, cost, c))
+	}
+	return best
+}
+
+func randomPositions(rng *rand.Rand) []int64 {
+	positions := make([]int64, 1+rng.Intn(30))
+	offset := int64(rng.Intn(100) - 50)
+	for i := range positions {
+		positions[i] = offset + int64(rng.Intn(60))
+	}
+	return positions
+}
+
+func TestOptimizeMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		positions := randomPositions(rng)
+		for _, cost := range testCosts {
+			want := bruteForce(positions, cost)
+			center, got := Optimize(positions, cost)
+			if got != want {
+				t.Errorf("%s cost of %v: Optimize gives %d, want %d", cost.Name, positions, got, want)
+			}
+			if total := totalCost(positions, cost, center); total != got {
+				t.Errorf("%s cost of %v: center %d costs %d, but Optimize said %d", cost.Name, positions, center, total, got)
+			}
+		}
+	}
+}
+
+func TestPrefixSumsMatchTotals(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 50; i++ {
+		positions := randomPositions(rng)
+		sorted := append([]int64(nil), positions...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		for _, cost := range testCosts {
+			if cost.coeffs == nil {
+				continue
+			}
+			sums := newPrefixSums(sorted, len(cost.coeffs)-1)
+			// including centers outside the crabs, where every crab is on one side
+			for c := sorted[0] - 5; c <= sorted[len(sorted)-1]+5; c++ {
+				if got, want := sums.total(cost, c), totalCost(positions, cost, c); got != want {
+					t.Errorf("%s cost of %v at %d: prefix sums give %d, want %d", cost.Name, positions, c, got, want)
+				}
+			}
+		}
+	}
+}
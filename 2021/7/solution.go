@@ -3,12 +3,10 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
-	"math"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 )
@@ -28,54 +26,46 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
-
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+	if err := RunSolver[[]int64](crabAlignment{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var (
 	inputFormat = regexp.MustCompile("(?P<sData>.*)")
 )
 
-func parseIntArrayOrDie(text string) []int64 {
+func parseIntArray(text string) ([]int64, error) {
 	res := make([]int64, 0)
 	text = strings.TrimSpace(text)
 	elements := strings.Split(text, ",")
 
-	for _, e := range elements {
-		i, err := strconv.ParseInt(e, 10, 64)
+	for i, e := range elements {
+		n, err := strconv.ParseInt(e, 10, 64)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("element %d: %w", i+1, err)
 		}
-		res = append(res, i)
+		res = append(res, n)
 	}
 
-	return res
+	return res, nil
 }
 
 type parsedParams struct {
@@ -113,78 +103,32 @@ func extractRegexp(pattern *regexp.Regexp, str string) (*parsedParams, error) {
 	return params, nil
 }
 
-func part1(input *scanner) int64 {
-	// Setup
-	var crabs []int64
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		crabs = parseIntArrayOrDie(line)
-		break
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
-	}
-
-	minCrab := int64(999999999)
-	maxCrab := int64(-99999999)
-	for _, c := range crabs {
-		if minCrab > c {
-			minCrab = c
-		}
-		if maxCrab < c {
-			maxCrab = c
-		}
-	}
-
-	//	bestStart := int64(0)
-	bestScore := int64(9999999999999)
-	for center := minCrab; center <= maxCrab; center++ {
-		thisScore := int64(0)
-		for _, c := range crabs {
-			thisScore += int64(math.Abs(float64(c - center)))
-		}
-		if thisScore < bestScore {
-			bestScore = thisScore
-		}
-	}
-
-	return bestScore
-}
+type crabAlignment struct{}
 
-func part2(input *scanner) int64 {
-	// Setup
-	var crabs []int64
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		crabs = parseIntArrayOrDie(line)
-		break
+func (crabAlignment) Parse(input io.Reader) ([]int64, error) {
+	scanner := newScanner(input)
+	line, ok := scanner.NextLine()
+	if err := scanner.Finish(); err != nil {
+		return nil, err
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	if !ok {
+		return nil, fmt.Errorf("input is empty")
 	}
-
-	minCrab := int64(999999999)
-	maxCrab := int64(-99999999)
-	for _, c := range crabs {
-		if minCrab > c {
-			minCrab = c
-		}
-		if maxCrab < c {
-			maxCrab = c
-		}
+	crabs, err := parseIntArray(line)
+	if err != nil {
+		return nil, fmt.Errorf("crab positions: %w", err)
 	}
+	return crabs, nil
+}
 
-	//	bestStart := int64(0)
-	bestScore := int64(9999999999999)
-	for center := minCrab; center <= maxCrab; center++ {
-		thisScore := int64(0)
-		for _, c := range crabs {
-			distance := int64(math.Abs(float64(c - center)))
-			cost := distance * (distance + 1) / 2
-			thisScore += cost
-		}
-		if thisScore < bestScore {
-			bestScore = thisScore
-		}
-	}
+func (crabAlignment) Part1(crabs []int64) (Answer, error) {
+	center, cost := Optimize(crabs, LinearCost)
+	log.Printf("best center for %s cost: %d", LinearCost.Name, center)
+	return cost, nil
+}
 
-	return bestScore
+func (crabAlignment) Part2(crabs []int64) (Answer, error) {
+	center, cost := Optimize(crabs, TriangularCost)
+	log.Printf("best center for %s cost: %d", TriangularCost.Name, center)
+	return cost, nil
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// The values a variable might still take, as a bitset; value v is bit v, so
+// values are limited to 0-63.
+type Domain uint64
+
+func DomainOf(values ...int) Domain {
+	d := Domain(0)
+	for _, v := range values {
+		d |= 1 << v
+	}
+	return d
+}
+
+func (d Domain) Has(v int) bool { return d&(1<<v) != 0 }
+func (d Domain) Size() int      { return bits.OnesCount64(uint64(d)) }
+
+// The lowest value in the domain, or -1 if it's empty.
+func (d Domain) Min() int {
+	if d == 0 {
+		return -1
+	}
+	return bits.TrailingZeros64(uint64(d))
+}
+
+func (d Domain) Values() []int {
+	values := make([]int, 0, d.Size())
+	for rest := d; rest != 0; rest &= rest - 1 {
+		values = append(values, rest.Min())
+	}
+	return values
+}
+
+// Narrows the domains of (some of) the variables. It must never remove a
+// value that's part of a solution; returning false means it found the
+// domains can't be satisfied.
+type Constraint func(domains []Domain) bool
+
+type Problem struct {
+	domains     []Domain
+	constraints []Constraint
+}
+
+// A problem with n variables, each of which starts out able to take any value
+// in initial.
+func NewProblem(n int, initial Domain) *Problem {
+	p := &Problem{domains: make([]Domain, n)}
+	for i := range p.domains {
+		p.domains[i] = initial
+	}
+	return p
+}
+
+func (p *Problem) Restrict(variable int, d Domain) {
+	p.domains[variable] &= d
+}
+
+func (p *Problem) AddConstraint(c Constraint) {
+	p.constraints = append(p.constraints, c)
+}
+
+// Every variable takes a different value.
+func AllDifferent() Constraint {
+	return func(domains []Domain) bool {
+		for i, d := range domains {
+			if d.Size() != 1 {
+				continue
+			}
+			for j := range domains {
+				if j != i {
+					domains[j] &^= d
+				}
+			}
+		}
+		return true
+	}
+}
+
+// Runs every constraint until none of them narrow anything further.
+func (p *Problem) propagate(domains []Domain) bool {
+	for changed := true; changed; {
+		changed = false
+		for _, c := range p.constraints {
+			before := make([]Domain, len(domains))
+			copy(before, domains)
+			if !c(domains) {
+				return false
+			}
+			for i := range domains {
+				if domains[i] == 0 {
+					return false
+				}
+				if domains[i] != before[i] {
+					changed = true
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Finds up to limit solutions (all of them, if limit is 0), each giving the
+// value of every variable. Searches by propagating constraints, then guessing
+// a value for the variable with the fewest options and backtracking if that
+// leads to a contradiction.
+func (p *Problem) Solve(limit int) [][]int {
+	solutions := [][]int{}
+	domains := make([]Domain, len(p.domains))
+	copy(domains, p.domains)
+	p.search(domains, limit, &solutions)
+	return solutions
+}
+
+func (p *Problem) search(domains []Domain, limit int, solutions *[][]int) {
+	if !p.propagate(domains) {
+		return
+	}
+
+	branchOn := -1
+	for i, d := range domains {
+		if d.Size() > 1 && (branchOn == -1 || d.Size() < domains[branchOn].Size()) {
+			branchOn = i
+		}
+	}
+	if branchOn == -1 {
+		solution := make([]int, len(domains))
+		for i, d := range domains {
+			solution[i] = d.Min()
+		}
+		*solutions = append(*solutions, solution)
+		return
+	}
+
+	for _, v := range domains[branchOn].Values() {
+		if limit > 0 && len(*solutions) >= limit {
+			return
+		}
+		guess := make([]Domain, len(domains))
+		copy(guess, domains)
+		guess[branchOn] = DomainOf(v)
+		p.search(guess, limit, solutions)
+	}
+}
+
+func (d Domain) String() string {
+	return fmt.Sprint(d.Values())
+}
@@ -3,10 +3,9 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
-	"runtime"
 	"sort"
 	"strings"
 )
@@ -26,62 +25,81 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
+	if err := RunSolver[[]Display](sevenSegmentSearch{}); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+// One line of the notes: the ten unique patterns, and the output digits.
+type Display struct {
+	Samples []string
+	Outputs []string
 }
 
-func part1(input *scanner) int {
-	// Setup
-	lineNo := 0
-	digitCount := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		inputHalves := strings.Split(line, " | ")
-		if len(inputHalves) != 2 {
-			panic(inputHalves)
+func parseDisplay(line string) (Display, error) {
+	inputHalves := strings.Split(line, " | ")
+	if len(inputHalves) != 2 {
+		return Display{}, fmt.Errorf("expected samples and output separated by \" | \"")
+	}
+	d := Display{
+		Samples: strings.Fields(inputHalves[0]),
+		Outputs: strings.Fields(inputHalves[1]),
+	}
+	for _, pattern := range append(d.Samples, d.Outputs...) {
+		if strings.Trim(pattern, wires) != "" {
+			return Display{}, fmt.Errorf("pattern %q has wires outside %s", pattern, wires)
 		}
+	}
+	return d, nil
+}
 
-		outputDigits := strings.Split(strings.TrimSpace(inputHalves[1]), " ")
-		// 		startCount := digitCount
-		for _, d := range outputDigits {
-			if len(d) == 2 || len(d) == 4 || len(d) == 7 || len(d) == 3 {
-				digitCount++
-			}
-		}
-		//		log.Printf("found %d matches in %v", digitCount-startCount, outputDigits)
+type sevenSegmentSearch struct{}
 
+func (sevenSegmentSearch) Parse(input io.Reader) ([]Display, error) {
+	scanner := newScanner(input)
+	lineNo := 0
+	displays := []Display{}
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
 		lineNo++
+		d, err := parseDisplay(line)
+		if err != nil {
+			return nil, fmt.Errorf("input line %d: %w", lineNo, err)
+		}
+		displays = append(displays, d)
 	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
+	if err := scanner.Finish(); err != nil {
+		return nil, err
 	}
+	return displays, nil
+}
 
-	return digitCount
+func (sevenSegmentSearch) Part1(displays []Display) (Answer, error) {
+	digitCount := 0
+	for _, d := range displays {
+		for _, digit := range d.Outputs {
+			if len(digit) == 2 || len(digit) == 4 || len(digit) == 7 || len(digit) == 3 {
+				digitCount++
+			}
+		}
+	}
+	return digitCount, nil
 }
 
 var validCombinations = map[string]int{
@@ -97,179 +115,133 @@ var validCombinations = map[string]int{
 	"abcdfg":  9,
 }
 
-func SortChars(s string) string {
-	slice := strings.Split(s, "")
-	sort.Sort(sort.StringSlice(slice))
-	return strings.Join(slice, "")
-}
-
-// Applies the character->character mapping in 'code' to 's'.
-// Example: if s is "ab" and code is {"a": "f", "b": "d"}, this will return "fd"
-func ApplyShuffle(s string, code map[string]string) string {
-	slice := strings.Split(s, "")
-	for i, char := range slice {
-		var ok bool
-		slice[i], ok = code[char]
-		if !ok {
-			log.Fatalf("encoding %v doesn't contain a mapping for char %s, needed for translating %s", code, char, s)
-		}
-	}
-	return strings.Join(slice, "")
-}
+const wires = "abcdefg"
 
-// Generates all permutations of 'arr'. Similar to Python's
-// itertools.permutations, but materializes all permutations in memory, and
-// builds them recursively without memoization, so don't use this with large
-// arrays.
-func PermuteArray(arr []string) [][]string {
-	if len(arr) == 0 {
-		return [][]string{}
+// The segments of each digit, as wires are numbered on an unscrambled display.
+var digitSegments = func() [10]Domain {
+	var digits [10]Domain
+	for segments, digit := range validCombinations {
+		digits[digit] = wireSet(segments)
 	}
+	return digits
+}()
 
-	if len(arr) == 1 {
-		return [][]string{arr}
-	}
+var allSegments = DomainOf(0, 1, 2, 3, 4, 5, 6)
 
-	if len(arr) == 2 {
-		return [][]string{
-			{arr[0], arr[1]},
-			{arr[1], arr[0]},
-		}
+func wireSet(pattern string) Domain {
+	d := Domain(0)
+	for _, w := range pattern {
+		d |= DomainOf(strings.IndexRune(wires, w))
 	}
+	return d
+}
 
-	if len(arr) == 3 {
-		return [][]string{
-			{arr[0], arr[1], arr[2]},
-			{arr[0], arr[2], arr[1]},
-			{arr[1], arr[0], arr[2]},
-			{arr[1], arr[2], arr[0]},
-			{arr[2], arr[0], arr[1]},
-			{arr[2], arr[1], arr[0]},
+// The wires lit in the pattern must drive exactly the segments of one of the
+// digits. Each wire's domain is the set of segments it might be connected to,
+// so this narrows lit wires to segments used by digits the pattern could
+// still be, and unlit wires to segments those digits leave dark. That's
+// enough to work out, say, that the wire in 7 but not in 1 must be the top
+// segment.
+func patternConstraint(pattern string) Constraint {
+	lit := wireSet(pattern)
+	return func(domains []Domain) bool {
+		litOptions := Domain(0)
+		darkOptions := Domain(0)
+		for _, digit := range digitSegments {
+			if digit.Size() != lit.Size() {
+				continue
+			}
+			feasible := true
+			covered := Domain(0)
+			for w := range domains {
+				if lit.Has(w) {
+					covered |= domains[w] & digit
+					feasible = feasible && domains[w]&digit != 0
+				} else {
+					feasible = feasible && domains[w]&^digit != 0
+				}
+			}
+			if feasible && covered == digit {
+				litOptions |= digit
+				darkOptions |= allSegments &^ digit
+			}
 		}
-	}
-
-	// recursive case: try putting each element first, and shuffling what's left.
-	possibilities := [][]string{}
-	for i := range arr {
-		rest := make([]string, len(arr)-1)
-		copy(rest, arr[:i])
-		copy(rest[i:], arr[i+1:])
-		for _, subPossibility := range PermuteArray(rest) {
-			thisPossibility := append([]string{arr[i]}, subPossibility...)
-			possibilities = append(possibilities, thisPossibility)
+		for w := range domains {
+			if lit.Has(w) {
+				domains[w] &= litOptions
+			} else {
+				domains[w] &= darkOptions
+			}
 		}
+		return litOptions != 0
 	}
-	return possibilities
 }
 
-// Returns all possible mappings from one char (in "abcdefg") to another.
-func getCharacterShuffles() []map[string]string {
-	shuffles := []map[string]string{}
-
-	chars := strings.Split("abcdefg", "")
-	permutations := PermuteArray(chars)
-	log.Printf("Generated %d permutations of %s", len(permutations), chars)
-
-	for _, permutation := range permutations {
-		thisShuffle := make(map[string]string)
-		for i := range chars {
-			thisShuffle[chars[i]] = permutation[i]
+// Reads the pattern as a digit, given which segment each wire drives.
+func decodeDigit(pattern string, wiring []int) (int, bool) {
+	segments := Domain(0)
+	for _, w := range pattern {
+		segments |= DomainOf(wiring[strings.IndexRune(wires, w)])
+	}
+	for digit, d := range digitSegments {
+		if d == segments {
+			return digit, true
 		}
-		shuffles = append(shuffles, thisShuffle)
 	}
-
-	return shuffles
+	return -1, false
 }
 
-// Each element of this slice is a copy of validCombinations with the segment
-// IDs permuted. Each string is re-sorted after permuting, so an encoded
-// (input) digit can be looked up directly if its encoded segment IDs are also
-// sorted.
-func getAllPossibleMappings() []map[string]int {
-	shuffledChars := getCharacterShuffles()
-
-	permutations := []map[string]int{}
-	for _, shuffle := range shuffledChars {
-		newPermutation := make(map[string]int)
-		for s, val := range validCombinations {
-			newPermutation[SortChars(ApplyShuffle(s, shuffle))] = val
+// Works out the wiring from every pattern on the display (the ten samples,
+// and the output digits too, in case the samples are incomplete), and decodes
+// the output. Fails if no wiring fits, or if wirings that fit disagree about
+// the output.
+func decodeDisplay(d Display) (int, error) {
+	outputDigits := d.Outputs
+
+	problem := NewProblem(len(wires), allSegments)
+	problem.AddConstraint(AllDifferent())
+	for _, pattern := range append(append([]string{}, d.Samples...), outputDigits...) {
+		problem.AddConstraint(patternConstraint(pattern))
+	}
+
+	outputs := map[int]struct{}{}
+	solutions := problem.Solve(0)
+	for _, wiring := range solutions {
+		output := 0
+		for _, pattern := range outputDigits {
+			digit, ok := decodeDigit(pattern, wiring)
+			if !ok {
+				return 0, fmt.Errorf("wiring %v satisfied every constraint but doesn't decode %s", wiring, pattern)
+			}
+			output = output*10 + digit
 		}
-		permutations = append(permutations, newPermutation)
+		outputs[output] = struct{}{}
 	}
-	return permutations
-}
 
-func isValidMapping(mapping map[string]int, inputDigits map[string]int) bool {
-	for d := range inputDigits {
-		if _, ok := mapping[d]; !ok {
-			return false
+	switch len(outputs) {
+	case 0:
+		return 0, fmt.Errorf("no wiring is consistent with every pattern")
+	case 1:
+		for output := range outputs {
+			return output, nil
 		}
 	}
-	return true
+	possible := []int{}
+	for output := range outputs {
+		possible = append(possible, output)
+	}
+	sort.Ints(possible)
+	return 0, fmt.Errorf("ambiguous: %d wirings fit, giving outputs %v", len(solutions), possible)
 }
 
-func part2(input *scanner) int {
-	// There are only 7! = 5040 possible wirings, so we can brute-force this.
-	// It would be /really/ cool to write a solver that worked out the problem
-	// like a human, eliminating possibilities as we go (and could handle
-	// certain input cases that don't include all 10 digits!), but this is a
-	// much simpler puzzle than that.
-	var possibleMappings []map[string]int = getAllPossibleMappings()
-
+func (sevenSegmentSearch) Part2(displays []Display) (Answer, error) {
 	totalOutput := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		inputHalves := strings.Split(line, " | ")
-		if len(inputHalves) != 2 {
-			panic(inputHalves)
+	for i, d := range displays {
+		output, err := decodeDisplay(d)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", i+1, err)
 		}
-
-		inputDigits := make(map[string]int)
-		outputDigits := make([]string, 0)
-
-		for i := 0; i < 2; i++ {
-			for _, d := range strings.Split(strings.TrimSpace(inputHalves[i]), " ") {
-				if i == 0 {
-					inputDigits[SortChars(d)] = -1
-				}
-				if i == 1 {
-					outputDigits = append(outputDigits, SortChars(d))
-				}
-			}
-		}
-		for _, d := range outputDigits {
-			if _, ok := inputDigits[d]; !ok {
-				log.Fatalf("didn't see matching input for %s: %s", d, line)
-			}
-		}
-
-		// Work out which mapping this case is using.
-		foundValid := false
-		for _, p := range possibleMappings {
-			if isValidMapping(p, inputDigits) {
-				for d := range inputDigits {
-					inputDigits[d] = p[d]
-				}
-				foundValid = true
-				break
-			}
-		}
-		if !foundValid {
-			log.Fatalf("couldn't find correct match for %s", line)
-		}
-
-		// Decode the output
-		thisOutputNum := 0
-		for _, d := range outputDigits {
-			if inputDigits[d] == -1 {
-				log.Fatalf("didn't decode %s in line: %s", d, line)
-			}
-			thisOutputNum *= 10
-			thisOutputNum += inputDigits[d]
-		}
-
-		// Add to our total.
-		totalOutput += thisOutputNum
+		totalOutput += output
 	}
-
-	return totalOutput
+	return totalOutput, nil
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Writes a random height map shaped like real inputs: low points scattered
+// about, each with a basin rising around it, and walls of 9 wherever two
+// basins meet. Random digits would make one basin cover nearly everything.
+func generateHeightMap(w io.Writer, rng *rand.Rand, width, height int) error {
+	if width < 1 || height < 1 {
+		return fmt.Errorf("the map needs at least one row and column, not %dx%d", width, height)
+	}
+	lows := make([]Point, max(3, width*height/40))
+	for i := range lows {
+		lows[i] = Point{row: rng.Intn(height), col: rng.Intn(width)}
+	}
+
+	// which low point each point is nearest, and how far it is
+	nearest := make([][]int, height)
+	dist := make([][]int, height)
+	for r := range nearest {
+		nearest[r] = make([]int, width)
+		dist[r] = make([]int, width)
+		for c := range nearest[r] {
+			dist[r][c] = -1
+			for i, l := range lows {
+				if d := abs(l.row-r) + abs(l.col-c); dist[r][c] == -1 || d < dist[r][c] {
+					nearest[r][c], dist[r][c] = i, d
+				}
+			}
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	for r := range nearest {
+		for c := range nearest[r] {
+			// of two neighbours in different basins, the one nearer a later
+			// low point is the wall
+			digit := min(dist[r][c], 8)
+			for _, n := range []Point{{row: r - 1, col: c}, {row: r + 1, col: c}, {row: r, col: c - 1}, {row: r, col: c + 1}} {
+				if n.row >= 0 && n.row < height && n.col >= 0 && n.col < width && nearest[n.row][n.col] < nearest[r][c] {
+					digit = 9
+				}
+			}
+			out.WriteByte(byte('0' + digit))
+		}
+		out.WriteByte('\n')
+	}
+	return out.Flush()
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
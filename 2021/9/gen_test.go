@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// The product of the three largest basins, found by a depth-first search from
+// every point not yet seen.
+func referenceBasins(space [][]int) int {
+	seen := make(map[Point]bool)
+	var fill func(p Point) int
+	fill = func(p Point) int {
+		if p.row < 0 || p.row >= len(space) || p.col < 0 || p.col >= len(space[0]) || seen[p] || space[p.row][p.col] == 9 {
+			return 0
+		}
+		seen[p] = true
+		return 1 + fill(Point{row: p.row - 1, col: p.col}) + fill(Point{row: p.row + 1, col: p.col}) +
+			fill(Point{row: p.row, col: p.col - 1}) + fill(Point{row: p.row, col: p.col + 1})
+	}
+	sizes := []int{}
+	for r := range space {
+		for c := range space[r] {
+			if size := fill(Point{row: r, col: c}); size > 0 {
+				sizes = append(sizes, size)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return sizes[0] * sizes[1] * sizes[2]
+}
+
+func TestSolveGeneratedHeightMap(t *testing.T) {
+	// Part2 logs every basin's size
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		var sb strings.Builder
+		if err := generateHeightMap(&sb, rand.New(rand.NewSource(seed)), 100, 100); err != nil {
+			t.Fatal(err)
+		}
+		space, err := smokeBasin{}.Parse(strings.NewReader(sb.String()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := (smokeBasin{}).Part1(space); err != nil {
+			t.Errorf("seed %d: part 1: %v", seed, err)
+		}
+		got, err := smokeBasin{}.Part2(space)
+		if err != nil {
+			t.Fatalf("seed %d: part 2: %v", seed, err)
+		}
+		if want := referenceBasins(space); got != want {
+			t.Errorf("seed %d: part 2 = %v, want %d", seed, got, want)
+		}
+	}
+}
+
+// Random digits make one basin of almost everything, which the flood fill
+// has to get through without queueing any point twice.
+func TestBasinsOfRandomDigits(t *testing.T) {
+	var sb strings.Builder
+	if err := generateDigitGrid(&sb, rand.New(rand.NewSource(1)), 200, 200, 0, 9); err != nil {
+		t.Fatal(err)
+	}
+	space, err := smokeBasin{}.Parse(strings.NewReader(sb.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := basins(space)
+	for r := range space {
+		for c := range space[r] {
+			if walled := space[r][c] == 9; walled != (labels[r][c] == -1) {
+				t.Fatalf("(%d, %d) has height %d but basin %d", r, c, space[r][c], labels[r][c])
+			}
+		}
+	}
+}
@@ -2,15 +2,14 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"image/color"
+	"io"
 	"log"
+	"math/rand"
 	"os"
-	"path/filepath"
-	"regexp"
-	"runtime"
 	"sort"
-	"strconv"
-	"strings"
 )
 
 type scanner struct {
@@ -28,65 +27,49 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
-}
-
-func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
-
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 var (
-	inputFormat = regexp.MustCompile("(?P<sData>.*)")
+	generate = flag.Bool("generate", false, "instead of solving, print a random height map to use as input")
+	seed     = flag.Int64("seed", 1, "random seed for -generate")
+	width    = flag.Int("width", 100, "how wide a map -generate makes")
+	height   = flag.Int("height", 100, "how tall a map -generate makes")
 )
 
-func part1(input *scanner) int {
-	// Setup
-	lineNo := 0
-
-	space := [][]int64{}
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		nums := make([]int64, len(line))
-		numStrs := strings.Split(line, "")
-		for i, s := range numStrs {
-			var err error
-			nums[i], err = strconv.ParseInt(s, 10, 64)
-			if err != nil {
-				log.Fatal(err)
-			}
+func main() {
+	flag.Parse()
+	if *generate {
+		if err := generateHeightMap(os.Stdout, rand.New(rand.NewSource(*seed)), *width, *height); err != nil {
+			log.Fatal(err)
 		}
-		space = append(space, nums)
-
-		// Process line
-		lineNo++
+		return
 	}
-	if err := input.Finish(); err != nil {
+	if err := RunSolver[[][]int](smokeBasin{}); err != nil {
 		log.Fatal(err)
 	}
+}
+
+type smokeBasin struct{}
 
+func (smokeBasin) Parse(input io.Reader) ([][]int, error) {
+	return readDigitGrid(input)
+}
+
+func (smokeBasin) Part1(space [][]int) (Answer, error) {
 	totalRisk := 0
 	for r, row := range space {
 		for c, cell := range row {
@@ -94,60 +77,44 @@ func part1(input *scanner) int {
 				(c == 0 || row[c-1] > cell) &&
 				(c == len(row)-1 || row[c+1] > cell) &&
 				(r == len(space)-1 || space[r+1][c] > cell) {
-				totalRisk += int(cell + 1)
+				totalRisk += cell + 1
 			}
 		}
 	}
 
-	return totalRisk
+	return totalRisk, nil
 }
 
-func part2(input *scanner) int {
-	// Setup
-	lineNo := 0
-
-	space := [][]int64{}
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
-		// Parse line into components
-		nums := make([]int64, len(line))
-		numStrs := strings.Split(line, "")
-		for i, s := range numStrs {
-			var err error
-			nums[i], err = strconv.ParseInt(s, 10, 64)
-			if err != nil {
-				log.Fatal(err)
-			}
-		}
-		space = append(space, nums)
-		lineNo++
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
-	}
-
-	width := len(space[0])
-	height := len(space)
-	basinAssignments := make([][]int, height)
-	for r := 0; r < height; r++ {
-		basinAssignments[r] = make([]int, width)
-		for c := 0; c < width; c++ {
+// Labels every point with the basin it drains into, numbered from 0, or -1
+// for the walls (height 9) between them.
+func basins(space [][]int) [][]int {
+	rows := len(space)
+	cols := len(space[0])
+	basinAssignments := make([][]int, rows)
+	for r := 0; r < rows; r++ {
+		basinAssignments[r] = make([]int, cols)
+		for c := 0; c < cols; c++ {
 			basinAssignments[r][c] = -1
 		}
 	}
 
 	nextBasin := 0
-	for r := 0; r < height; r++ {
-		for c := 0; c < width; c++ {
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
 			if basinAssignments[r][c] == -1 && space[r][c] != 9 {
 				floodFrom(space, basinAssignments, r, c, nextBasin)
 				nextBasin++
 			}
 		}
 	}
+	return basinAssignments
+}
 
+func (smokeBasin) Part2(space [][]int) (Answer, error) {
+	basinAssignments := basins(space)
 	basinSizes := make(map[int]int)
-	for r := 0; r < height; r++ {
-		for c := 0; c < width; c++ {
+	for r := range basinAssignments {
+		for c := range basinAssignments[r] {
 			if basinAssignments[r][c] != -1 {
 				basinSizes[basinAssignments[r][c]]++
 			}
@@ -161,7 +128,10 @@ func part2(input *scanner) int {
 	}
 	sort.Sort(sort.IntSlice(sortedCounts))
 
-	return sortedCounts[len(sortedCounts)-1] * sortedCounts[len(sortedCounts)-2] * sortedCounts[len(sortedCounts)-3]
+	if len(sortedCounts) < 3 {
+		return nil, fmt.Errorf("only %d basins, need at least 3", len(sortedCounts))
+	}
+	return sortedCounts[len(sortedCounts)-1] * sortedCounts[len(sortedCounts)-2] * sortedCounts[len(sortedCounts)-3], nil
 }
 
 type Point struct {
@@ -169,16 +139,18 @@ type Point struct {
 	col int
 }
 
-func floodFrom(space [][]int64, assignments [][]int, row int, col int, label int) {
+// Labels every point reachable from (row, col) without crossing a 9. Points
+// are labelled as they're queued, so none is queued twice.
+func floodFrom(space [][]int, assignments [][]int, row int, col int, label int) {
 	queue := []Point{
 		{row: row, col: col},
 	}
+	assignments[row][col] = label
 	for len(queue) > 0 {
 		thisPoint := queue[0]
 		queue = queue[1:]
 		//		log.Println(thisPoint)
 
-		assignments[thisPoint.row][thisPoint.col] = label
 		neighbors := []Point{
 			{row: thisPoint.row + 1, col: thisPoint.col},
 			{row: thisPoint.row - 1, col: thisPoint.col},
@@ -189,9 +161,24 @@ func floodFrom(space [][]int64, assignments [][]int, row int, col int, label int
 			if n.row >= 0 && n.row < len(space) && n.col >= 0 && n.col < len(space[n.row]) {
 				// real point
 				if assignments[n.row][n.col] == -1 && space[n.row][n.col] != 9 {
+					assignments[n.row][n.col] = label
 					queue = append(queue, n)
 				}
 			}
 		}
 	}
 }
+
+// Draws the basins, each in its own color.
+func (smokeBasin) Draw(space [][]int, path string) error {
+	basinAssignments := basins(space)
+	// walls are dark; basins cycle through the colors
+	pal := append(color.Palette{color.RGBA{R: 20, G: 20, B: 30, A: 255}}, Categorical(63)...)
+	img := RenderGrid(len(space[0]), len(space), 4, pal, func(x, y int) int {
+		if basinAssignments[y][x] == -1 {
+			return 0
+		}
+		return 1 + basinAssignments[y][x]%63
+	})
+	return WriteImage(path, img)
+}
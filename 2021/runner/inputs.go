@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Everyone's puzzle input for a day goes in that day's inputs directory as
+// <name>.txt, and the answers it should give in <name>.answers, written just
+// as the day prints them ("Part 1 solution: ..."), so recording them is a
+// matter of saving the output.
+const inputsDir = "inputs"
+
+// One task per named input of each day. Days without an inputs directory are
+// left out.
+func namedInputs(days []Task) ([]Task, error) {
+	tasks := []Task{}
+	for _, day := range days {
+		files, err := filepath.Glob(filepath.Join(day.Dir, inputsDir, "*.txt"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		for _, f := range files {
+			t := day
+			t.Input = strings.TrimSuffix(filepath.Base(f), ".txt")
+			t.InputPath = f
+			tasks = append(tasks, t)
+		}
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no days have any %s/<name>.txt", inputsDir)
+	}
+	return tasks, nil
+}
+
+func answersPath(t Task) string {
+	return strings.TrimSuffix(t.InputPath, ".txt") + ".answers"
+}
+
+// The recorded answers for a named input, or nil if there aren't any yet.
+func recordedAnswers(t Task) (map[int]string, error) {
+	contents, err := os.ReadFile(answersPath(t))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return parseAnswers(string(contents)), nil
+}
+
+// Saves the output of every successful run whose input has no answers yet.
+func recordAnswers(results []Result) error {
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		path := answersPath(r.Task)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(r.Output), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("recorded %s\n", path)
+	}
+	return nil
+}
+
+// How one part of one run compares with what was recorded.
+func partStatus(r Result, recorded map[int]string, part int) string {
+	got, ok := r.Answers[part]
+	switch {
+	case r.Err != nil:
+		return "err"
+	case recorded == nil:
+		return "?"
+	case !ok:
+		return "-"
+	case got == recorded[part]:
+		return "ok"
+	default:
+		return "FAIL"
+	}
+}
+
+// A row for each day and a column for each person, with whether each part
+// matched: ok, FAIL, err if the day didn't run, ? if there are no recorded
+// answers and - if the day didn't give one. Failures are listed underneath.
+// Returns whether everything matched.
+func printMatrix(w io.Writer, results []Result) bool {
+	names := []string{}
+	seen := map[string]bool{}
+	days := []string{}
+	cells := map[string]map[string]string{}
+	problems := []string{}
+	for _, r := range results {
+		if !seen[r.Task.Input] {
+			seen[r.Task.Input] = true
+			names = append(names, r.Task.Input)
+		}
+		if cells[r.Task.Day] == nil {
+			days = append(days, r.Task.Day)
+			cells[r.Task.Day] = map[string]string{}
+		}
+
+		recorded, err := recordedAnswers(r.Task)
+		if err != nil && r.Err == nil {
+			r.Err = err
+		}
+		statuses := []string{}
+		for part := 1; part <= 2; part++ {
+			status := partStatus(r, recorded, part)
+			statuses = append(statuses, status)
+			if status == "FAIL" {
+				problems = append(problems, fmt.Sprintf("day %s, %s, part %d: got %q, expected %q", r.Task.Day, r.Task.Input, part, r.Answers[part], recorded[part]))
+			}
+		}
+		if r.Err != nil {
+			problems = append(problems, fmt.Sprintf("day %s, %s: %v", r.Task.Day, r.Task.Input, r.Err))
+		}
+		cells[r.Task.Day][r.Task.Input] = strings.Join(statuses, "/")
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "%-5s", "day")
+	for _, n := range names {
+		fmt.Fprintf(w, " %-12s", n)
+	}
+	fmt.Fprintln(w)
+	passed := true
+	for _, d := range days {
+		fmt.Fprintf(w, "%-5s", d)
+		for _, n := range names {
+			cell, ok := cells[d][n]
+			if !ok {
+				cell = ""
+			} else if cell != "ok/ok" {
+				passed = false
+			}
+			fmt.Fprintf(w, " %-12s", cell)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, p := range problems {
+		fmt.Fprintln(w, p)
+	}
+	return passed
+}
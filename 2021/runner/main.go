@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	workers = flag.Int("j", runtime.NumCPU(), "how many days to run at once")
+	timeout = flag.Duration("timeout", 2*time.Minute, "give up on a day after this long")
+	days    = flag.String("days", "", "comma-separated days to run (default: all of them)")
+	verbose = flag.Bool("v", false, "print each day's full output, not just its answers")
+	inputs  = flag.Bool("inputs", false, "run every day on each of its inputs/<name>.txt, check the answers against <name>.answers, and print a table of which passed")
+	record  = flag.Bool("record", false, "with -inputs, save the answers for any input that doesn't have a <name>.answers yet")
+)
+
+func main() {
+	flag.Parse()
+
+	// the days live next to this directory
+	_, thisFilePath, _, _ := runtime.Caller(0)
+	root := filepath.Dir(filepath.Dir(thisFilePath))
+
+	tasks, err := findDays(root, *days)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *inputs {
+		if tasks, err = namedInputs(tasks); err != nil {
+			log.Fatal(err)
+		}
+		results := runTasks(ctx, tasks)
+		if *record {
+			if err := recordAnswers(results); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if !printMatrix(os.Stdout, results) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	start := time.Now()
+	results := runTasks(ctx, tasks)
+	failed := 0
+	for _, r := range results {
+		printResult(r)
+		if r.Err != nil {
+			failed++
+		}
+	}
+	fmt.Printf("%d days in %v, %d failed\n", len(results), time.Since(start).Round(time.Millisecond), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// Every directory under root named for a day, in day order, or just the
+// ones asked for.
+func findDays(root string, only string) ([]Task, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	wanted := map[string]bool{}
+	for _, d := range strings.Split(only, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			wanted[d] = true
+		}
+	}
+
+	tasks := []Task{}
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err != nil || !e.IsDir() {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[e.Name()] {
+			continue
+		}
+		tasks = append(tasks, Task{Day: e.Name(), Dir: filepath.Join(root, e.Name())})
+	}
+	for d := range wanted {
+		found := false
+		for _, t := range tasks {
+			found = found || t.Day == d
+		}
+		if !found {
+			return nil, fmt.Errorf("no day %s in %s", d, root)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		a, _ := strconv.Atoi(tasks[i].Day)
+		b, _ := strconv.Atoi(tasks[j].Day)
+		return a < b
+	})
+	return tasks, nil
+}
+
+// The day's Go files, leaving out tests; the days have no go.mod, so they
+// have to be named to go run.
+func sourceFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sources := []string{}
+	for _, f := range files {
+		if !strings.HasSuffix(f, "_test.go") {
+			sources = append(sources, filepath.Base(f))
+		}
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return sources, nil
+}
+
+// Builds each day once, however many inputs it's to be run on, and then runs
+// every task on the built days, both on the pool. A day that doesn't build
+// fails every one of its tasks.
+func runTasks(ctx context.Context, tasks []Task) []Result {
+	binDir, err := os.MkdirTemp("", "aoc")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(binDir)
+
+	days := []Task{}
+	seen := map[string]bool{}
+	for _, t := range tasks {
+		if !seen[t.Day] {
+			seen[t.Day] = true
+			days = append(days, Task{Day: t.Day, Dir: t.Dir})
+		}
+	}
+	buildErrs := map[string]error{}
+	for _, r := range RunAll(ctx, days, *workers, *timeout, func(ctx context.Context, day Task) Result {
+		return Result{Err: buildDay(ctx, day, binPath(binDir, day))}
+	}) {
+		buildErrs[r.Task.Day] = r.Err
+	}
+
+	return RunAll(ctx, tasks, *workers, *timeout, func(ctx context.Context, task Task) Result {
+		if err := buildErrs[task.Day]; err != nil {
+			return Result{Err: err}
+		}
+		return runDay(ctx, task, binPath(binDir, task))
+	})
+}
+
+func binPath(binDir string, task Task) string {
+	return filepath.Join(binDir, "day"+task.Day)
+}
+
+// Builds the day to bin. It's built separately rather than with go run so
+// that, if it times out, it's the solution itself that gets killed.
+func buildDay(ctx context.Context, day Task, bin string) error {
+	sources, err := sourceFiles(day.Dir)
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	build := exec.CommandContext(ctx, "go", append([]string{"build", "-o", bin}, sources...)...)
+	build.Dir = day.Dir
+	build.Stderr = &stderr
+	if err := build.Run(); err != nil {
+		return commandError(ctx, "building", err, &stderr)
+	}
+	return nil
+}
+
+// Runs the day's built solution on the task's input. Every day opens its
+// input with openInput from the shared solver.go, which reads AOC_INPUT
+// before falling back to the day's own input.txt.
+func runDay(ctx context.Context, task Task, bin string) Result {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin)
+	cmd.Dir = task.Dir
+	if task.InputPath != "" {
+		cmd.Env = append(os.Environ(), "AOC_INPUT="+task.InputPath)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	result := Result{Output: stdout.String(), Answers: parseAnswers(stdout.String())}
+	if err != nil {
+		result.Err = commandError(ctx, "running", err, &stderr)
+	}
+	return result
+}
+
+func commandError(ctx context.Context, doing string, err error, stderr *bytes.Buffer) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out %s", doing)
+	}
+	return fmt.Errorf("%s: %v\n%s", doing, err, lastLines(stderr.String(), 5))
+}
+
+var answerLine = regexp.MustCompile(`^Part (\d+) solution: ?(.*)$`)
+
+// Picks the answers out of a day's output. An answer that starts on the line
+// after its label (like day 13's folded paper) runs until the next label.
+func parseAnswers(output string) map[int]string {
+	answers := map[int]string{}
+	part := 0
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		if m := answerLine.FindStringSubmatch(line); m != nil {
+			part, _ = strconv.Atoi(m[1])
+			answers[part] = m[2]
+		} else if part != 0 && (answers[part] == "" || strings.Contains(answers[part], "\n")) {
+			answers[part] += "\n" + line
+		}
+	}
+	for p, a := range answers {
+		answers[p] = strings.Trim(a, "\n")
+	}
+	return answers
+}
+
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func printResult(r Result) {
+	status := "ok"
+	if r.Err != nil {
+		status = "FAIL"
+	}
+	fmt.Printf("day %-3s %-4s %8v", r.Task.Day, status, r.Duration.Round(time.Millisecond))
+	parts := make([]int, 0, len(r.Answers))
+	for p := range r.Answers {
+		parts = append(parts, p)
+	}
+	sort.Ints(parts)
+	for _, p := range parts {
+		a := r.Answers[p]
+		if strings.Contains(a, "\n") {
+			a = "(" + strconv.Itoa(strings.Count(a, "\n")+1) + " lines)"
+		}
+		fmt.Printf("  part %d: %s", p, a)
+	}
+	fmt.Println()
+	if r.Err != nil {
+		fmt.Printf("    %s\n", strings.ReplaceAll(r.Err.Error(), "\n", "\n    "))
+	}
+	if *verbose {
+		fmt.Printf("    %s\n", strings.ReplaceAll(strings.TrimRight(r.Output, "\n"), "\n", "\n    "))
+	}
+}
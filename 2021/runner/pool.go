@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// One run of one day's solution, on its own input.txt or on a named input.
+type Task struct {
+	Day string
+	Dir string
+
+	Input     string
+	InputPath string
+}
+
+type Result struct {
+	Task     Task
+	Answers  map[int]string
+	Output   string
+	Duration time.Duration
+	Err      error
+}
+
+// Runs every task on at most workers goroutines at once, giving each one
+// timeout to finish, and returns the results in the same order as the tasks
+// however they happen to finish. A task that panics fails on its own without
+// taking the rest down with it. Once ctx is done, tasks that haven't started
+// fail straight away.
+func RunAll(ctx context.Context, tasks []Task, workers int, timeout time.Duration, run func(context.Context, Task) Result) []Result {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]Result, len(tasks))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = runOne(ctx, tasks[i], timeout, run)
+			}
+		}()
+	}
+	for i := range tasks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+func runOne(ctx context.Context, task Task, timeout time.Duration, run func(context.Context, Task) Result) (result Result) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result = Result{Task: task, Err: fmt.Errorf("panicked: %v\n%s", r, debug.Stack())}
+		}
+		result.Task = task
+		result.Duration = time.Since(start)
+	}()
+
+	if err := ctx.Err(); err != nil {
+		return Result{Err: err}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return run(ctx, task)
+}
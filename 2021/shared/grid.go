@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+// Writes a width x height grid of random digits from lo to hi, one row per
+// line, as the grid days' puzzle inputs are laid out.
+func generateDigitGrid(w io.Writer, rng *rand.Rand, width, height, lo, hi int) error {
+	if lo < 0 || hi > 9 || lo > hi {
+		return fmt.Errorf("digits must be from 0 to 9, not %d to %d", lo, hi)
+	}
+	out := bufio.NewWriter(w)
+	row := make([]byte, width)
+	for y := 0; y < height; y++ {
+		for x := range row {
+			row[x] = byte('0' + lo + rng.Intn(hi-lo+1))
+		}
+		out.Write(row)
+		out.WriteByte('\n')
+	}
+	return out.Flush()
+}
+
+// Reads a grid of digits, one row per line, every row the same length.
+func readDigitGrid(r io.Reader) ([][]int, error) {
+	grid := [][]int{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if len(line) == 0 {
+			continue
+		}
+		if len(grid) > 0 && len(line) != len(grid[0]) {
+			return nil, fmt.Errorf("line %d: %d digits, but the first line has %d", len(grid)+1, len(line), len(grid[0]))
+		}
+		row := make([]int, len(line))
+		for i, c := range line {
+			if c < '0' || c > '9' {
+				return nil, fmt.Errorf("line %d: %q isn't a digit", len(grid)+1, c)
+			}
+			row[i] = int(c - '0')
+		}
+		grid = append(grid, row)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(grid) == 0 {
+		return nil, fmt.Errorf("no rows")
+	}
+	return grid, nil
+}
@@ -0,0 +1,17 @@
+package main
+
+// Shrinks a failing test case: keeps taking the first of shrinks(c) that
+// still fails until none of them does, and returns where it got to, which is
+// usually much easier to read than what it started from.
+func minimize[C any](c C, shrinks func(C) []C, fails func(C) bool) C {
+	for shrunk := true; shrunk; {
+		shrunk = false
+		for _, s := range shrinks(c) {
+			if fails(s) {
+				c, shrunk = s, true
+				break
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMinimize(t *testing.T) {
+	// drop one element at a time; it fails while the sum is at least 10
+	shrinks := func(c []int) [][]int {
+		out := [][]int{}
+		for i := range c {
+			out = append(out, append(append([]int{}, c[:i]...), c[i+1:]...))
+		}
+		return out
+	}
+	fails := func(c []int) bool {
+		sum := 0
+		for _, v := range c {
+			sum += v
+		}
+		return sum >= 10
+	}
+	for _, tc := range []struct {
+		start, want []int
+	}{
+		{[]int{1, 2, 3, 4, 5}, []int{3, 4, 5}},
+		{[]int{10}, []int{10}},
+		{[]int{3, 3, 3, 3}, []int{3, 3, 3, 3}},
+	} {
+		if got := minimize(tc.start, shrinks, fails); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("minimize(%v) = %v, want %v", tc.start, got, tc.want)
+		}
+	}
+}
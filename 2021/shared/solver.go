@@ -0,0 +1,179 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// What a part of a puzzle comes to: usually a number, sometimes a picture.
+type Answer any
+
+// A day's puzzle, split so the input is parsed once into a T that both parts
+// share (and that can be built some other way to try the parts out).
+type Solver[T any] interface {
+	Parse(input io.Reader) (T, error)
+	Part1(parsed T) (Answer, error)
+	Part2(parsed T) (Answer, error)
+}
+
+// A day that can draw its puzzle, for -viz.
+type Drawer[T any] interface {
+	// Writes a picture of the parsed input (and usually its solution) to
+	// path.
+	Draw(parsed T, path string) error
+}
+
+// A day with something else to do with its input first, such as animating
+// it or answering a question about it instead of solving it.
+type BeforeSolver[T any] interface {
+	// Called with the input parsed for solving, which it mustn't change;
+	// returns whether to go on and solve it.
+	BeforeSolving(parsed T) (solve bool, err error)
+}
+
+// A day with something else to do once it's solved, such as writing out how
+// it got its answers.
+type AfterSolver[T any] interface {
+	// Called with the input the parts were given.
+	AfterSolving(parsed T) error
+}
+
+var vizPath = flag.String("viz", "", "after solving, draw the puzzle to this image file, on days that can (see their Draw)")
+
+// How long each phase of solving took.
+type Timings struct {
+	Parse time.Duration
+	Part1 time.Duration
+	Part2 time.Duration
+}
+
+// Opens input.txt from next to this file, or whatever file AOC_INPUT names
+// (such as one of the inputs/<name>.txt the runner's -inputs mode uses).
+func openInput() (*os.File, error) {
+	path := os.Getenv("AOC_INPUT")
+	if path == "" {
+		_, thisFilePath, _, _ := runtime.Caller(0)
+		path = filepath.Join(filepath.Dir(thisFilePath), "input.txt")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening input file: %w", err)
+	}
+	return f, nil
+}
+
+// Reads the day's input with openInput and parses it.
+func readInput[T any](s Solver[T]) (T, error) {
+	f, err := openInput()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+	parsed, err := s.Parse(f)
+	if err != nil {
+		return parsed, fmt.Errorf("parsing %s: %w", f.Name(), err)
+	}
+	return parsed, nil
+}
+
+// Writes one answer the way every day always has; an answer that runs over
+// several lines starts on the line after its label.
+func printAnswer(w io.Writer, part int, answer Answer) {
+	text := fmt.Sprint(answer)
+	if strings.Contains(text, "\n") {
+		text = "\n" + text
+	}
+	fmt.Fprintf(w, "Part %d solution: %s\n", part, text)
+}
+
+// Parses the input once, solves both parts with it, and writes the answers
+// to w. Stops at the first error, but any answer found before it is written.
+func Solve[T any](s Solver[T], input io.Reader, w io.Writer) (Timings, error) {
+	_, timings, err := solve(s, input, w)
+	return timings, err
+}
+
+func solve[T any](s Solver[T], input io.Reader, w io.Writer) (T, Timings, error) {
+	parsed, timings, err := parse(s, input)
+	if err != nil {
+		return parsed, timings, err
+	}
+	err = solveParsed(s, parsed, w, &timings)
+	return parsed, timings, err
+}
+
+func parse[T any](s Solver[T], input io.Reader) (T, Timings, error) {
+	timings := Timings{}
+	start := time.Now()
+	parsed, err := s.Parse(input)
+	timings.Parse = time.Since(start)
+	if err != nil {
+		return parsed, timings, fmt.Errorf("parsing: %w", err)
+	}
+	return parsed, timings, nil
+}
+
+func solveParsed[T any](s Solver[T], parsed T, w io.Writer, timings *Timings) error {
+	parts := []func(T) (Answer, error){s.Part1, s.Part2}
+	durations := []*time.Duration{&timings.Part1, &timings.Part2}
+	for i, part := range parts {
+		start := time.Now()
+		answer, err := part(parsed)
+		*durations[i] = time.Since(start)
+		if err != nil {
+			return fmt.Errorf("part %d: %w", i+1, err)
+		}
+		printAnswer(w, i+1, answer)
+	}
+	return nil
+}
+
+// Solves the day's own input, printing the answers, and logs how long it
+// took. The input is parsed once, and the same parsed input goes to the
+// day's BeforeSolving, its parts, its AfterSolving and, with -viz, its Draw.
+func RunSolver[T any](s Solver[T]) error {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	drawer, canDraw := s.(Drawer[T])
+	if *vizPath != "" && !canDraw {
+		return fmt.Errorf("-viz: this day has nothing to draw")
+	}
+
+	f, err := openInput()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	parsed, timings, err := parse(s, f)
+	if err != nil {
+		return err
+	}
+	if before, ok := s.(BeforeSolver[T]); ok {
+		if goOn, err := before.BeforeSolving(parsed); err != nil || !goOn {
+			return err
+		}
+	}
+	err = solveParsed(s, parsed, os.Stdout, &timings)
+	log.Printf("parsed in %v, part 1 in %v, part 2 in %v", timings.Parse, timings.Part1, timings.Part2)
+	if err != nil {
+		return err
+	}
+	if after, ok := s.(AfterSolver[T]); ok {
+		if err := after.AfterSolving(parsed); err != nil {
+			return err
+		}
+	}
+	if *vizPath == "" {
+		return nil
+	}
+	return drawer.Draw(parsed, *vizPath)
+}
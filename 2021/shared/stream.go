@@ -0,0 +1,178 @@
+package main
+
+import "fmt"
+
+// A source of values that are read one at a time, so that nothing has to be
+// held in memory beyond what an operator needs.
+type Stream[T any] interface {
+	Next() (T, bool)
+}
+
+type Number interface {
+	~int | ~int32 | ~int64 | ~float64
+}
+
+type funcStream[T any] func() (T, bool)
+
+func (f funcStream[T]) Next() (T, bool) { return f() }
+
+func FromSlice[T any](values []T) Stream[T] {
+	i := 0
+	return funcStream[T](func() (T, bool) {
+		if i >= len(values) {
+			var zero T
+			return zero, false
+		}
+		i++
+		return values[i-1], true
+	})
+}
+
+func Collect[T any](s Stream[T]) []T {
+	values := []T{}
+	for v, ok := s.Next(); ok; v, ok = s.Next() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// A stream that ends early if converting a value fails; check Err once it's
+// done.
+type ErrStream[T any] struct {
+	next func() (T, bool, error)
+	err  error
+}
+
+func (s *ErrStream[T]) Next() (T, bool) {
+	var zero T
+	if s.err != nil {
+		return zero, false
+	}
+	v, ok, err := s.next()
+	if err != nil {
+		s.err = err
+		return zero, false
+	}
+	return v, ok
+}
+
+func (s *ErrStream[T]) Err() error {
+	return s.err
+}
+
+func Map[T, U any](s Stream[T], f func(T) (U, error)) *ErrStream[U] {
+	return &ErrStream[U]{
+		next: func() (U, bool, error) {
+			var zero U
+			v, ok := s.Next()
+			if !ok {
+				return zero, false, nil
+			}
+			u, err := f(v)
+			return u, err == nil, err
+		},
+	}
+}
+
+// Each run of k consecutive values, oldest first. Every window is a new
+// slice, so callers can keep them.
+func Windows[T any](s Stream[T], k int) (Stream[[]T], error) {
+	if k < 1 {
+		return nil, fmt.Errorf("window size must be at least 1, not %d", k)
+	}
+	ring := make([]T, k)
+	seen := 0
+	return funcStream[[]T](func() ([]T, bool) {
+		for {
+			v, ok := s.Next()
+			if !ok {
+				return nil, false
+			}
+			ring[seen%k] = v
+			seen++
+			if seen >= k {
+				window := make([]T, k)
+				for i := range window {
+					window[i] = ring[(seen+i)%k]
+				}
+				return window, true
+			}
+		}
+	}), nil
+}
+
+type Pair[T any] struct {
+	Prev T
+	Cur  T
+}
+
+// Each value together with the one before it.
+func Pairwise[T any](s Stream[T]) Stream[Pair[T]] {
+	var prev T
+	started := false
+	return funcStream[Pair[T]](func() (Pair[T], bool) {
+		for {
+			v, ok := s.Next()
+			if !ok {
+				return Pair[T]{}, false
+			}
+			if !started {
+				prev, started = v, true
+				continue
+			}
+			p := Pair[T]{Prev: prev, Cur: v}
+			prev = v
+			return p, true
+		}
+	})
+}
+
+// The total of everything so far, after each value.
+func RunningSum[T Number](s Stream[T]) Stream[T] {
+	var total T
+	return funcStream[T](func() (T, bool) {
+		v, ok := s.Next()
+		if !ok {
+			return total, false
+		}
+		total += v
+		return total, true
+	})
+}
+
+// The sum of each run of k consecutive values, kept up to date as the window
+// slides rather than re-added each time.
+func WindowSums[T Number](s Stream[T], k int) (Stream[T], error) {
+	if k < 1 {
+		return nil, fmt.Errorf("window size must be at least 1, not %d", k)
+	}
+	ring := make([]T, k)
+	seen := 0
+	var total T
+	return funcStream[T](func() (T, bool) {
+		for {
+			v, ok := s.Next()
+			if !ok {
+				return total, false
+			}
+			total += v - ring[seen%k]
+			ring[seen%k] = v
+			seen++
+			if seen >= k {
+				return total, true
+			}
+		}
+	}), nil
+}
+
+// How many values are bigger than the one before.
+func CountIncreases[T Number](s Stream[T]) int {
+	count := 0
+	pairs := Pairwise(s)
+	for p, ok := pairs.Next(); ok; p, ok = pairs.Next() {
+		if p.Cur > p.Prev {
+			count++
+		}
+	}
+	return count
+}
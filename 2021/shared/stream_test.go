@@ -0,0 +1,117 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWindows(t *testing.T) {
+	for _, tc := range []struct {
+		values []int
+		k      int
+		want   [][]int
+	}{
+		{[]int{1, 2, 3, 4}, 1, [][]int{{1}, {2}, {3}, {4}}},
+		{[]int{1, 2, 3, 4}, 3, [][]int{{1, 2, 3}, {2, 3, 4}}},
+		{[]int{1, 2, 3, 4}, 4, [][]int{{1, 2, 3, 4}}},
+		{[]int{1, 2, 3}, 4, [][]int{}},
+		{nil, 2, [][]int{}},
+	} {
+		windows, err := Windows(FromSlice(tc.values), tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Collect(windows); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Windows(%v, %d) = %v, want %v", tc.values, tc.k, got, tc.want)
+		}
+	}
+}
+
+func TestWindowsKeepTheirValues(t *testing.T) {
+	windows, err := Windows(FromSlice([]int{1, 2, 3}), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := windows.Next()
+	windows.Next()
+	if want := []int{1, 2}; !reflect.DeepEqual(first, want) {
+		t.Errorf("first window changed to %v after the next, want %v", first, want)
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	for _, tc := range []struct {
+		values []int
+		want   []Pair[int]
+	}{
+		{[]int{1, 5, 2}, []Pair[int]{{Prev: 1, Cur: 5}, {Prev: 5, Cur: 2}}},
+		{[]int{7}, []Pair[int]{}},
+		{nil, []Pair[int]{}},
+	} {
+		if got := Collect(Pairwise(FromSlice(tc.values))); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Pairwise(%v) = %v, want %v", tc.values, got, tc.want)
+		}
+	}
+}
+
+func TestRunningSum(t *testing.T) {
+	for _, tc := range []struct {
+		values []int
+		want   []int
+	}{
+		{[]int{1, 2, 3, -4}, []int{1, 3, 6, 2}},
+		{nil, []int{}},
+	} {
+		if got := Collect(RunningSum(FromSlice(tc.values))); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("RunningSum(%v) = %v, want %v", tc.values, got, tc.want)
+		}
+	}
+}
+
+func TestWindowSums(t *testing.T) {
+	depths := []int{199, 200, 208, 210, 200, 207, 240, 269, 260, 263}
+	for _, tc := range []struct {
+		values []int
+		k      int
+		want   []int
+	}{
+		{depths, 3, []int{607, 618, 618, 617, 647, 716, 769, 792}},
+		{[]int{4, -1, 2}, 1, []int{4, -1, 2}},
+		{[]int{1, 2}, 3, []int{}},
+	} {
+		sums, err := WindowSums(FromSlice(tc.values), tc.k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Collect(sums); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("WindowSums(%v, %d) = %v, want %v", tc.values, tc.k, got, tc.want)
+		}
+	}
+}
+
+func TestWindowSizeMustBePositive(t *testing.T) {
+	for _, k := range []int{0, -1} {
+		if _, err := Windows(FromSlice([]int{1}), k); err == nil {
+			t.Errorf("Windows with k = %d: no error", k)
+		}
+		if _, err := WindowSums(FromSlice([]int{1}), k); err == nil {
+			t.Errorf("WindowSums with k = %d: no error", k)
+		}
+	}
+}
+
+func TestCountIncreases(t *testing.T) {
+	for _, tc := range []struct {
+		values []int
+		want   int
+	}{
+		{[]int{199, 200, 208, 210, 200, 207, 240, 269, 260, 263}, 7},
+		{[]int{3, 3, 3}, 0},
+		{[]int{1}, 0},
+		{nil, 0},
+	} {
+		if got := CountIncreases(FromSlice(tc.values)); got != tc.want {
+			t.Errorf("CountIncreases(%v) = %d, want %d", tc.values, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+//go:build ignore
+
+// The days have no go.mod, so they can't import a package of shared code.
+// Instead, each file in this directory is the one copy to edit, and this
+// writes it out into every day that uses it, marked as generated:
+//
+//	go run shared/sync.go         (from 2021)
+//	go run shared/sync.go -check  (fails if any copy is out of date)
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// Which days get each file, as paths from 2021.
+var copies = map[string][]string{
+	"grid.go":        {"9", "11", "15"},
+	"shrink.go":      {"6", "14"},
+	"shrink_test.go": {"6", "14"},
+	"solver.go":      {"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "../template"},
+	"stream.go":      {"1", "14"},
+	"stream_test.go": {"1", "14"},
+	"term.go":        {"11", "13"},
+	"term_test.go":   {"11", "13"},
+	"viz.go":         {"9", "11", "13", "15"},
+}
+
+var check = flag.Bool("check", false, "only report copies that don't match their source, without writing anything")
+
+func header(name string) []byte {
+	return []byte(fmt.Sprintf("// Code generated by shared/sync.go from shared/%s; DO NOT EDIT.\n\n", name))
+}
+
+func main() {
+	flag.Parse()
+	_, thisFilePath, _, _ := runtime.Caller(0)
+	shared := filepath.Dir(thisFilePath)
+	root := filepath.Dir(shared)
+
+	names := make([]string, 0, len(copies))
+	for name := range copies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stale := 0
+	for _, name := range names {
+		source, err := os.ReadFile(filepath.Join(shared, name))
+		if err != nil {
+			log.Fatal(err)
+		}
+		want := append(header(name), source...)
+		for _, day := range copies[name] {
+			path := filepath.Join(root, day, name)
+			if have, err := os.ReadFile(path); err == nil && bytes.Equal(have, want) {
+				continue
+			}
+			if *check {
+				fmt.Printf("%s is out of date\n", path)
+				stale++
+				continue
+			}
+			if err := os.WriteFile(path, want, 0644); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	if stale > 0 {
+		os.Exit(1)
+	}
+}
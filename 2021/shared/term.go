@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Returned by Draw once the viewer presses q.
+var ErrQuit = errors.New("quit")
+
+const (
+	screenHelp = "space: pause/resume  n: step  +/-: speed  q: quit"
+	cellDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// Draws a grid over and over in the same place on a terminal, or writes each
+// frame as plain text for later comparison.
+type Screen struct {
+	FPS float64
+
+	out      *bufio.Writer
+	file     io.Closer
+	live     bool
+	keys     chan byte
+	timer    *time.Timer
+	paused   bool
+	frame    int
+	lastLine string
+	closed   bool
+
+	// for giving the terminal back
+	restore  string
+	restored sync.Once
+	signals  chan os.Signal
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// Takes over the terminal: the cursor's hidden, keys are read as they're
+// pressed, and frames are shown fps times a second. Close gives it back, as
+// does Ctrl-C or being killed.
+func NewLiveScreen(fps float64) (*Screen, error) {
+	if fps <= 0 {
+		return nil, fmt.Errorf("frame rate must be positive, not %g", fps)
+	}
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("keyboard controls need a terminal: %w", err)
+	}
+	// reads give up after a tenth of a second without a key, so the reader
+	// can notice it's been closed
+	if _, err := stty("cbreak", "-echo", "min", "0", "time", "1"); err != nil {
+		return nil, fmt.Errorf("keyboard controls need a terminal: %w", err)
+	}
+	s := &Screen{
+		FPS:     fps,
+		out:     bufio.NewWriter(os.Stdout),
+		live:    true,
+		keys:    make(chan byte),
+		restore: strings.TrimSpace(saved),
+		signals: make(chan os.Signal, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	signal.Notify(s.signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-s.signals:
+			s.restoreTerminal()
+			code := 1
+			if n, ok := sig.(syscall.Signal); ok {
+				code = 128 + int(n)
+			}
+			os.Exit(code)
+		case <-s.done:
+		}
+	}()
+	go s.readKeys(s.keys)
+	// clear the screen and hide the cursor
+	s.out.WriteString("\x1b[2J\x1b[?25l")
+	return s, s.out.Flush()
+}
+
+// Sends each key pressed to keys, until the screen's closed. It gets its own
+// reference to the channel, since wait forgets it once stdin's gone.
+func (s *Screen) readKeys(keys chan<- byte) {
+	defer close(s.stopped)
+	defer close(keys)
+	buf := make([]byte, 1)
+	for {
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+		n, err := os.Stdin.Read(buf)
+		if n == 0 {
+			// a read that timed out comes back empty, as if at the end
+			if err == nil || err == io.EOF {
+				continue
+			}
+			return
+		}
+		select {
+		case keys <- buf[0]:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Writes every frame to w as text, one digit (or letter, past 9, or + past
+// z) per cell giving its palette index, without waiting between them.
+func NewHeadlessScreen(w io.Writer) *Screen {
+	return &Screen{out: bufio.NewWriter(w)}
+}
+
+// A headless screen writing to a new file at path, which Close closes.
+func CreateHeadlessScreen(path string) (*Screen, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewHeadlessScreen(f)
+	s.file = f
+	return s, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// Shows the next frame: each cell is colored by the palette index that cell
+// returns (clamped to the palette), with the caption underneath. A live
+// screen then waits until the next frame is due, or for as long as it's
+// paused.
+func (s *Screen) Draw(width, height int, pal color.Palette, cell func(x, y int) int, caption string) error {
+	s.frame++
+	if !s.live {
+		fmt.Fprintf(s.out, "frame %d: %s\n", s.frame, caption)
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				idx := clampIndex(cell(x, y), len(pal))
+				if idx < len(cellDigits) {
+					s.out.WriteByte(cellDigits[idx])
+				} else {
+					s.out.WriteByte('+')
+				}
+			}
+			s.out.WriteByte('\n')
+		}
+		s.out.WriteByte('\n')
+		return s.out.Flush()
+	}
+
+	// back to the top left, then two spaces per cell so they come out
+	// roughly square
+	s.out.WriteString("\x1b[H")
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := pal[clampIndex(cell(x, y), len(pal))].RGBA()
+			fmt.Fprintf(s.out, "\x1b[48;2;%d;%d;%dm  ", r>>8, g>>8, b>>8)
+		}
+		s.out.WriteString("\x1b[0m\x1b[K\n")
+	}
+	s.lastLine = caption
+	if err := s.status(); err != nil {
+		return err
+	}
+	return s.wait()
+}
+
+func clampIndex(idx, n int) int {
+	if idx < 0 {
+		return 0
+	} else if idx >= n {
+		return n - 1
+	}
+	return idx
+}
+
+func (s *Screen) status() error {
+	state := fmt.Sprintf("%.3g fps", s.FPS)
+	if s.paused {
+		state = "paused"
+	}
+	// and clear whatever's left below from a bigger frame
+	fmt.Fprintf(s.out, "\x1b[Kframe %d: %s [%s]\n\x1b[K%s\n\x1b[J", s.frame, s.lastLine, state, screenHelp)
+	return s.out.Flush()
+}
+
+func (s *Screen) interval() time.Duration {
+	return time.Duration(float64(time.Second) / s.FPS)
+}
+
+// Handles keys until it's time for the next frame.
+func (s *Screen) wait() error {
+	if s.timer == nil {
+		s.timer = time.NewTimer(s.interval())
+	} else {
+		s.timer.Reset(s.interval())
+	}
+	for {
+		due := s.timer.C
+		if s.paused {
+			due = nil
+		}
+		select {
+		case <-due:
+			return nil
+		case key, ok := <-s.keys:
+			if !ok {
+				// stdin's gone, so nobody can unpause
+				s.keys, s.paused = nil, false
+				continue
+			}
+			switch key {
+			case ' ':
+				s.paused = !s.paused
+				if !s.paused {
+					s.timer.Reset(s.interval())
+				}
+			case 'n', '.':
+				if s.paused {
+					return nil
+				}
+			case '+', '=':
+				s.FPS *= 2
+				s.timer.Reset(s.interval())
+			case '-', '_':
+				s.FPS /= 2
+				s.timer.Reset(s.interval())
+			case 'q':
+				return ErrQuit
+			default:
+				continue
+			}
+			// move back up over the status lines to redraw them
+			s.out.WriteString("\x1b[2A")
+			if err := s.status(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Shows the cursor and puts the terminal settings back, once, whether from
+// Close or a signal.
+func (s *Screen) restoreTerminal() error {
+	var err error
+	s.restored.Do(func() {
+		os.Stdout.WriteString("\x1b[0m\x1b[?25h")
+		_, err = stty(s.restore)
+	})
+	return err
+}
+
+// Puts the terminal back the way it was, or closes the file a headless
+// screen was writing to. Closing twice does nothing.
+func (s *Screen) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.out.Flush()
+	if s.live {
+		close(s.done)
+		<-s.stopped
+		signal.Stop(s.signals)
+		if s.timer != nil {
+			s.timer.Stop()
+		}
+		if rerr := s.restoreTerminal(); err == nil {
+			err = rerr
+		}
+	}
+	if s.file != nil {
+		if cerr := s.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Shrinks a grid to fit in maxWidth x maxHeight cells, if it doesn't already,
+// by adding up the values in each block of cells that end up together.
+func Downsample(width, height, maxWidth, maxHeight int, value func(x, y int) int) (int, int, func(x, y int) int) {
+	block := 1
+	for (width+block-1)/block > maxWidth || (height+block-1)/block > maxHeight {
+		block++
+	}
+	if block == 1 {
+		return width, height, value
+	}
+	return (width + block - 1) / block, (height + block - 1) / block, func(x, y int) int {
+		total := 0
+		for dy := 0; dy < block && y*block+dy < height; dy++ {
+			for dx := 0; dx < block && x*block+dx < width; dx++ {
+				total += value(x*block+dx, y*block+dy)
+			}
+		}
+		return total
+	}
+}
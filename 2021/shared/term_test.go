@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func grayPalette(n int) color.Palette {
+	pal := make(color.Palette, n)
+	for i := range pal {
+		pal[i] = color.Gray{Y: uint8(i)}
+	}
+	return pal
+}
+
+func TestHeadlessDraw(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewHeadlessScreen(&out)
+	values := [][]int{{-3, 0, 9}, {10, 35, 36}}
+	cell := func(x, y int) int { return values[y][x] }
+	if err := screen.Draw(3, 2, grayPalette(40), cell, "first"); err != nil {
+		t.Fatal(err)
+	}
+	// a smaller palette clamps everything past its end
+	if err := screen.Draw(3, 2, grayPalette(10), cell, "second"); err != nil {
+		t.Fatal(err)
+	}
+	if err := screen.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "frame 1: first\n" +
+		"009\n" +
+		"az+\n" +
+		"\n" +
+		"frame 2: second\n" +
+		"009\n" +
+		"999\n" +
+		"\n"
+	if got := out.String(); got != want {
+		t.Errorf("frames:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewHeadlessScreen(&out)
+	if err := screen.Draw(1, 1, grayPalette(2), func(x, y int) int { return 1 }, "only"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := screen.Close(); err != nil {
+			t.Fatalf("close %d: %v", i+1, err)
+		}
+	}
+	if want := "frame 1: only\n1\n\n"; out.String() != want {
+		t.Errorf("frames = %q, want %q", out.String(), want)
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	// each cell's value is unique, so a wrong block shows up in the sums
+	grid := func(x, y int) int { return 10*y + x }
+	for _, tc := range []struct {
+		name                string
+		width, height       int
+		maxWidth, maxHeight int
+		want                [][]int
+	}{
+		{"fits already", 2, 2, 2, 2, [][]int{{0, 1}, {10, 11}}},
+		{"fits with room to spare", 2, 1, 10, 10, [][]int{{0, 1}}},
+		{"even blocks", 4, 2, 2, 1, [][]int{{0 + 1 + 10 + 11, 2 + 3 + 12 + 13}}},
+		{"partial blocks at the edges", 3, 3, 2, 2, [][]int{
+			{0 + 1 + 10 + 11, 2 + 12},
+			{20 + 21, 22},
+		}},
+		{"too wide only", 5, 1, 2, 5, [][]int{{0 + 1 + 2, 3 + 4}}},
+		{"too tall only", 1, 3, 5, 1, [][]int{{0 + 10 + 20}}},
+	} {
+		width, height, value := Downsample(tc.width, tc.height, tc.maxWidth, tc.maxHeight, grid)
+		got := make([][]int, height)
+		for y := range got {
+			got[y] = make([]int, width)
+			for x := range got[y] {
+				got[y][x] = value(x, y)
+			}
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: Downsample(%dx%d to %dx%d) = %v, want %v",
+				tc.name, tc.width, tc.height, tc.maxWidth, tc.maxHeight, got, tc.want)
+		}
+	}
+}
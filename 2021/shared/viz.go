@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Shades from one color to another in n steps.
+func Ramp(from, to color.RGBA, n int) color.Palette {
+	pal := make(color.Palette, n)
+	for i := range pal {
+		t := 0.0
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		mix := func(a, b uint8) uint8 { return uint8(float64(a) + t*(float64(b)-float64(a)) + 0.5) }
+		pal[i] = color.RGBA{R: mix(from.R, to.R), G: mix(from.G, to.G), B: mix(from.B, to.B), A: 255}
+	}
+	return pal
+}
+
+// n colors that are easy to tell apart, for labelling regions.
+func Categorical(n int) color.Palette {
+	pal := make(color.Palette, n)
+	for i := range pal {
+		// step round the hue circle by the golden angle
+		pal[i] = hsv(float64(i)*137.508, 0.65, 0.95)
+	}
+	return pal
+}
+
+func hsv(h, s, v float64) color.RGBA {
+	h = math.Mod(h, 360)
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+	var r, g, b float64
+	switch int(h / 60) {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{R: uint8((r + m) * 255), G: uint8((g + m) * 255), B: uint8((b + m) * 255), A: 255}
+}
+
+// Draws a width x height grid with each cell scale pixels square, colored
+// by the palette index that cell returns (clamped to the palette).
+func RenderGrid(width, height, scale int, pal color.Palette, cell func(x, y int) int) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, width*scale, height*scale), pal)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			idx := cell(x, y)
+			if idx < 0 {
+				idx = 0
+			} else if idx >= len(pal) {
+				idx = len(pal) - 1
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(x*scale+dx, y*scale+dy, uint8(idx))
+				}
+			}
+		}
+	}
+	return img
+}
+
+// Draws each point in fg on a bg canvas just big enough to hold them all
+// (and the origin).
+func RenderPoints(points []image.Point, scale int, bg, fg color.Color) *image.Paletted {
+	maxX, maxY := 0, 0
+	set := make(map[image.Point]struct{}, len(points))
+	for _, p := range points {
+		if p.X > maxX {
+			maxX = p.X
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+		set[p] = struct{}{}
+	}
+	return RenderGrid(maxX+1, maxY+1, scale, color.Palette{bg, fg}, func(x, y int) int {
+		if _, hit := set[image.Point{X: x, Y: y}]; hit {
+			return 1
+		}
+		return 0
+	})
+}
+
+func WritePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Collects frames of a simulation into an animated GIF.
+type Recorder struct {
+	// Time to show each frame, in hundredths of a second.
+	Delay int
+	// Every frame is held in memory until the GIF is written, so past this
+	// many (if it's set) the rest are dropped.
+	MaxFrames int
+
+	anim gif.GIF
+}
+
+// Adds a frame to the end, unless the recorder is already full; reports
+// whether there was room.
+func (r *Recorder) AddFrame(img *image.Paletted) bool {
+	if r.Full() {
+		return false
+	}
+	r.anim.Image = append(r.anim.Image, img)
+	r.anim.Delay = append(r.anim.Delay, r.Delay)
+	b := img.Bounds()
+	if b.Max.X > r.anim.Config.Width {
+		r.anim.Config.Width = b.Max.X
+	}
+	if b.Max.Y > r.anim.Config.Height {
+		r.anim.Config.Height = b.Max.Y
+	}
+	return true
+}
+
+func (r *Recorder) Frames() int {
+	return len(r.anim.Image)
+}
+
+func (r *Recorder) Full() bool {
+	return r.MaxFrames > 0 && len(r.anim.Image) >= r.MaxFrames
+}
+
+func (r *Recorder) WriteGIF(path string) error {
+	if len(r.anim.Image) == 0 {
+		return fmt.Errorf("no frames to write to %s", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := gif.EncodeAll(f, &r.anim); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// Writes a single image as PNG, or as a one-frame GIF if the path ends .gif.
+func WriteImage(path string, img *image.Paletted) error {
+	if strings.EqualFold(filepath.Ext(path), ".gif") {
+		r := &Recorder{}
+		r.AddFrame(img)
+		return r.WriteGIF(path)
+	}
+	return WritePNG(path, img)
+}
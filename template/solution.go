@@ -3,13 +3,11 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
 	"regexp"
-	"runtime"
 	"strconv"
-	"strings"
 )
 
 type scanner struct {
@@ -27,56 +25,32 @@ func (s *scanner) NextLine() (string, bool) {
 }
 
 func (s *scanner) Finish() error {
-	if s.file != nil {
+	if s.sc != nil {
 		s.err = s.sc.Err()
 
-		s.file.Close()
-		s.file = nil
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
 		s.sc = nil
 	}
 	return s.err
 }
 
-func getInputScanner() *scanner {
-	_, thisFilePath, _, _ := runtime.Caller(0)
-	f, err := os.OpenFile(filepath.Join(filepath.Dir(thisFilePath), "input.txt"), os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		log.Fatalf("error opening input file: %v", err)
-	}
-	return &scanner{
-		file: f,
-		sc:   bufio.NewScanner(f),
-	}
+func newScanner(r io.Reader) *scanner {
+	return &scanner{sc: bufio.NewScanner(r)}
 }
 
 func main() {
-	scanner := getInputScanner()
-	fmt.Printf("Part 1 solution: %d\n", part1(scanner))
-
-	scanner = getInputScanner()
-	fmt.Printf("Part 2 solution: %d\n", part2(scanner))
+	if err := RunSolver[[]*parsedParams](puzzle{}); err != nil {
+		log.Fatal(err)
+	}
 }
 
 var (
 	inputFormat = regexp.MustCompile("(?P<sData>.*)")
 )
 
-func parseIntArrayOrDie(text string) []int64 {
-	res := make([]int64, 0)
-	text = strings.TrimSpace(text)
-	elements := strings.Split(text, ",")
-
-	for _, e := range elements {
-		i, err := strconv.ParseInt(e, 10, 64)
-		if err != nil {
-			panic(err)
-		}
-		res = append(res, i)
-	}
-
-	return res
-}
-
 type parsedParams struct {
 	FullMatch string
 	Strings   map[string]string
@@ -112,27 +86,36 @@ func extractRegexp(pattern *regexp.Regexp, str string) (*parsedParams, error) {
 	return params, nil
 }
 
-func part1(input *scanner) int {
-	// Setup
-	lineNo := 0
-	for line, ok := input.NextLine(); ok; line, ok = input.NextLine() {
+type puzzle struct{}
+
+func (puzzle) Parse(input io.Reader) ([]*parsedParams, error) {
+	scanner := newScanner(input)
+	lineNo := 1
+	lines := []*parsedParams{}
+	for line, ok := scanner.NextLine(); ok; line, ok = scanner.NextLine() {
 		// Parse line into components
 		parsedLine, err := extractRegexp(inputFormat, line)
 		if err != nil {
-			log.Fatalf("input line %d: %v", lineNo, err)
+			return nil, fmt.Errorf("input line %d: %w", lineNo, err)
 		}
+		lines = append(lines, parsedLine)
+		lineNo++
+	}
+	if err := scanner.Finish(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
 
+func (puzzle) Part1(lines []*parsedParams) (Answer, error) {
+	for _, parsedLine := range lines {
 		// Process line
 		fmt.Printf("got line: %+v\n", parsedLine)
-		lineNo++
-	}
-	if err := input.Finish(); err != nil {
-		log.Fatal(err)
 	}
 
-	return lineNo
+	return len(lines), nil
 }
 
-func part2(input *scanner) int {
-	return 0
+func (puzzle) Part2(lines []*parsedParams) (Answer, error) {
+	return 0, nil
 }